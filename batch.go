@@ -0,0 +1,33 @@
+package uuid
+
+import "fmt"
+
+// AppendBinaryBatch appends the 16-byte representations of all the UUIDs to
+// dst consecutively and returns the extended slice. If dst has enough
+// capacity, no allocation is made.
+func AppendBinaryBatch(dst []byte, uuids []UUID) []byte {
+	if n := len(dst) + 16*len(uuids); n > cap(dst) {
+		buf := make([]byte, len(dst), n)
+		copy(buf, dst)
+		dst = buf
+	}
+	for i := range uuids {
+		dst = append(dst, uuids[i][:]...)
+	}
+	return dst
+}
+
+// DecodeBinaryBatch returns the UUIDs from data containing the consecutive
+// 16-byte representations, as written by AppendBinaryBatch. The UUIDs are
+// copied, so data may be reused after the call. Returns an error if the
+// length of data is not a multiple of 16.
+func DecodeBinaryBatch(data []byte) ([]UUID, error) {
+	if len(data)%16 != 0 {
+		return nil, fmt.Errorf("uuid: batch length must be a multiple of 16 bytes, got %d bytes", len(data))
+	}
+	uuids := make([]UUID, len(data)/16)
+	for i := range uuids {
+		copy(uuids[i][:], data[16*i:])
+	}
+	return uuids, nil
+}
@@ -0,0 +1,58 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendBinaryBatch(t *testing.T) {
+	uuids := []UUID{New(), New(), New()}
+	data := AppendBinaryBatch([]byte("prefix"), uuids)
+	if len(data) != 6+16*len(uuids) || string(data[:6]) != "prefix" {
+		t.Fatal("bad batch length", len(data))
+	}
+	for i, uuid := range uuids {
+		if !bytes.Equal(data[6+16*i:6+16*(i+1)], uuid.Bytes()) {
+			t.Error("bad batch", i)
+		}
+	}
+	if data := AppendBinaryBatch(nil, nil); len(data) != 0 {
+		t.Error("bad empty batch")
+	}
+}
+
+func TestDecodeBinaryBatch(t *testing.T) {
+	uuids := []UUID{New(), New(), New()}
+	data := AppendBinaryBatch(nil, uuids)
+	newUUIDs, err := DecodeBinaryBatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newUUIDs) != len(uuids) {
+		t.Fatal("bad batch length", len(newUUIDs))
+	}
+	data[0]++ // the result must not share memory with data
+	for i, uuid := range uuids {
+		if !uuid.Equal(newUUIDs[i]) {
+			t.Error("bad restore", i)
+		}
+	}
+	if _, err := DecodeBinaryBatch(data[:len(data)-1]); err == nil {
+		t.Error("bad truncated batch")
+	}
+	if newUUIDs, err := DecodeBinaryBatch(nil); err != nil || len(newUUIDs) != 0 {
+		t.Error("bad empty batch", err)
+	}
+}
+
+func BenchmarkAppendBinaryBatch(b *testing.B) {
+	uuids := make([]UUID, 10000)
+	for i := range uuids {
+		uuids[i] = New()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AppendBinaryBatch(make([]byte, 0, 16*len(uuids)), uuids)
+	}
+}
@@ -0,0 +1,26 @@
+package uuid
+
+import "fmt"
+
+// cborTag is the CBOR tag 37 (major type 6) registered for the binary UUID,
+// followed by the header of the 16-byte byte string (major type 2).
+const cborTag = "\xd8\x25\x50"
+
+// MarshalCBOR provides support for the Marshaler interface of the CBOR
+// packages, like github.com/fxamacker/cbor. The UUID is encoded as the
+// 16-byte byte string with the semantic tag 37.
+func (u UUID) MarshalCBOR() ([]byte, error) {
+	return append(append(make([]byte, 0, 19), cborTag...), u[:]...), nil
+}
+
+// UnmarshalCBOR provides support for the Unmarshaler interface of the CBOR
+// packages. Accepts the 16-byte byte string with or without the tag 37.
+func (u *UUID) UnmarshalCBOR(data []byte) error {
+	if len(data) == 19 && data[0] == cborTag[0] && data[1] == cborTag[1] {
+		data = data[2:]
+	}
+	if len(data) != 17 || data[0] != cborTag[2] {
+		return fmt.Errorf("uuid: invalid CBOR UUID: %x", data)
+	}
+	return u.UnmarshalBinary(data[1:])
+}
@@ -0,0 +1,39 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	uuid := New()
+	data, err := uuid.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append([]byte{0xd8, 0x25, 0x50}, uuid.Bytes()...)
+	if !bytes.Equal(data, want) {
+		t.Errorf("bad cbor % x", data)
+	}
+	for _, data := range [][]byte{data, data[2:]} {
+		var newUUID UUID
+		if err := newUUID.UnmarshalCBOR(data); err != nil {
+			t.Error(err)
+		}
+		if !newUUID.Equal(uuid) {
+			t.Error("bad restore", newUUID)
+		}
+	}
+	for _, data := range [][]byte{
+		nil,
+		data[:18],
+		append([]byte{0xd8, 0x26}, data[2:]...),
+		append([]byte{0x4f}, uuid.Bytes()[1:]...),
+		append([]byte{0x78, 0x24}, uuid.String()...),
+	} {
+		var newUUID UUID
+		if err := newUUID.UnmarshalCBOR(data); err == nil {
+			t.Errorf("bad cbor % x", data)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package uuid
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Encoding identifies the string form of a UUID used by Codec.
+type Encoding uint8
+
+// Supported encodings.
+const (
+	EncodingCanonical Encoding = iota // xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	EncodingHex                       // 32 lowercase hex digits without dashes
+	EncodingBase32                    // 26 chars of Crockford's base32 without padding
+	EncodingBase64                    // 22 chars of URL-safe base64 without padding
+	EncodingBase58                    // up to 22 chars of Bitcoin's base58
+)
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingCanonical:
+		return "canonical"
+	case EncodingHex:
+		return "hex"
+	case EncodingBase32:
+		return "base32"
+	case EncodingBase64:
+		return "base64"
+	case EncodingBase58:
+		return "base58"
+	default:
+		return fmt.Sprintf("Encoding(%d)", uint8(e))
+	}
+}
+
+var (
+	// base32Encoding is the Crockford's base32 alphabet without padding.
+	base32Encoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").
+			WithPadding(base32.NoPadding)
+	// base64Encoding is the URL-safe base64 alphabet without padding, which
+	// rejects the non-zero trailing bits, so every UUID has one spelling.
+	base64Encoding = base64.RawURLEncoding.Strict()
+	// base58Alphabet is the Bitcoin's base58 alphabet.
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// Codec converts the UUID to and from the string form with the selected
+// encoding. The zero value of the Codec uses the canonical form.
+type Codec struct {
+	Encoding Encoding
+}
+
+// Encode returns the string representation of the UUID in the codec encoding.
+// Returns an empty string for an unknown encoding.
+func (c Codec) Encode(u UUID) string {
+	switch c.Encoding {
+	case EncodingCanonical:
+		return u.String()
+	case EncodingHex:
+		return u.Hex()
+	case EncodingBase32:
+		return base32Encoding.EncodeToString(u[:])
+	case EncodingBase64:
+		return base64Encoding.EncodeToString(u[:])
+	case EncodingBase58:
+		return encodeBase58(u[:])
+	default:
+		return ""
+	}
+}
+
+// Decode parses the string representation of the UUID in the codec encoding.
+// The canonical encoding accepts all the forms supported by Parse, base32 is
+// case-insensitive. The base32 and base64 strings with non-zero unused bits
+// in the last character are rejected, so they are accepted only in the form
+// returned by Encode, up to the case of base32.
+func (c Codec) Decode(s string) (uuid UUID, err error) {
+	var data []byte
+	switch c.Encoding {
+	case EncodingCanonical:
+		return Parse(s)
+	case EncodingHex:
+		if len(s) != 32 {
+			return uuid, parseError(s, ErrInvalidLength)
+		}
+		if !decodeHex(&uuid, s, false) {
+			return uuid, parseError(s, ErrInvalidHex)
+		}
+		return uuid, nil
+	case EncodingBase32:
+		if len(s) != 26 {
+			return uuid, fmt.Errorf("uuid: invalid base32 UUID string: %s", s)
+		}
+		upper := strings.ToUpper(s)
+		data, err = base32Encoding.DecodeString(upper)
+		// base32 has no strict mode: the re-encoding rejects the non-zero
+		// unused bits of the last character
+		if err == nil && base32Encoding.EncodeToString(data) != upper {
+			return uuid, fmt.Errorf("uuid: non-canonical base32 UUID string: %s", s)
+		}
+	case EncodingBase64:
+		if len(s) != 22 {
+			return uuid, fmt.Errorf("uuid: invalid base64 UUID string: %s", s)
+		}
+		data, err = base64Encoding.DecodeString(s)
+	case EncodingBase58:
+		var decoded UUID
+		if err = decodeBase58(decoded[:], s); err != nil {
+			return Nil, err
+		}
+		return decoded, nil
+	default:
+		return uuid, fmt.Errorf("uuid: unknown encoding %v", c.Encoding)
+	}
+	if err != nil {
+		return uuid, err
+	}
+	err = uuid.UnmarshalBinary(data)
+	return uuid, err
+}
+
+// encodeBase58 returns the base58 representation of the src. Every leading
+// zero byte is encoded as a single '1'.
+func encodeBase58(src []byte) string {
+	var zeros int
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+	// log(256) / log(58) ≈ 1.37 digits for each byte
+	buf := make([]byte, len(src)*138/100+1)
+	high := len(buf) - 1
+	for _, b := range src[zeros:] {
+		carry := int(b)
+		j := len(buf) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += int(buf[j]) << 8
+			buf[j] = byte(carry % 58)
+			carry /= 58
+		}
+		high = j
+	}
+	var i int
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	out := make([]byte, zeros+len(buf)-i)
+	for j := 0; j < zeros; j++ {
+		out[j] = base58Alphabet[0]
+	}
+	for j, d := range buf[i:] {
+		out[zeros+j] = base58Alphabet[d]
+	}
+	return string(out)
+}
+
+// decodeBase58 decodes the base58 string s into dst. Returns an error if the
+// string contains invalid characters, does not fit in dst or is not the
+// canonical base58 representation of dst.
+func decodeBase58(dst []byte, s string) error {
+	for i := range dst {
+		dst[i] = 0
+	}
+	var ones int
+	for ones < len(s) && s[ones] == base58Alphabet[0] {
+		ones++
+	}
+	for i := 0; i < len(s); i++ {
+		carry := strings.IndexByte(base58Alphabet, s[i])
+		if carry < 0 {
+			return fmt.Errorf("uuid: invalid base58 UUID string: %s", s)
+		}
+		for j := len(dst) - 1; j >= 0; j-- {
+			carry += int(dst[j]) * 58
+			dst[j] = byte(carry)
+			carry >>= 8
+		}
+		if carry != 0 {
+			return fmt.Errorf("uuid: invalid base58 UUID string: %s", s)
+		}
+	}
+	var zeros int
+	for zeros < len(dst) && dst[zeros] == 0 {
+		zeros++
+	}
+	if zeros != ones {
+		return fmt.Errorf("uuid: invalid base58 UUID string: %s", s)
+	}
+	return nil
+}
+
+// Base64 returns the UUID as 22 characters of the URL-safe base64 alphabet
+// (RFC 4648 section 5) without padding, like Codec with EncodingBase64. It is
+// a compact form for URLs and display, not a UUID string defined by RFC 4122,
+// so only ParseBase64 accepts it.
+func (u UUID) Base64() string {
+	return (Codec{Encoding: EncodingBase64}).Encode(u)
+}
+
+// ParseBase64 parses the UUID from the 22 characters returned by Base64. Only
+// the exact output of Base64 is accepted: the string with non-zero unused bits
+// in the last character, which would decode to the same UUID, is rejected.
+func ParseBase64(s string) (UUID, error) {
+	return (Codec{Encoding: EncodingBase64}).Decode(s)
+}
+
+// Base32 returns the UUID as 26 characters of Crockford's base32 alphabet in
+// uppercase without padding, like Codec with EncodingBase32. The alphabet has
+// no I, L, O and U, so the result is easy to read and dictate. It is a compact
+// form for display, not a UUID string defined by RFC 4122, so only
+// ParseBase32 accepts it.
+func (u UUID) Base32() string {
+	return (Codec{Encoding: EncodingBase32}).Encode(u)
+}
+
+// ParseBase32 parses the UUID from the 26 characters returned by Base32 in any
+// case. The string with non-zero unused bits in the last character, which
+// would decode to the same UUID, is rejected.
+func ParseBase32(s string) (UUID, error) {
+	return (Codec{Encoding: EncodingBase32}).Decode(s)
+}
+
+// FlakeID returns the UUID in the form of the Elasticsearch document ID: the
+// 16 bytes in the original order encoded with the URL-safe base64 alphabet
+// (RFC 4648 section 5) without padding, which gives 22 characters. This is
+// the form used by Elasticsearch for random IDs (UUIDs.randomBase64UUID).
+// The 20-character time-based auto IDs of Elasticsearch contain only 15 bytes
+// and can't hold a UUID.
+func (u UUID) FlakeID() string {
+	return (Codec{Encoding: EncodingBase64}).Encode(u)
+}
+
+// ParseFlakeID parses the UUID from the Elasticsearch document ID returned by
+// FlakeID. Elasticsearch compares the IDs as strings, so only the exact output
+// of FlakeID is accepted, and the IDs differing only in the unused bits of the
+// last character are rejected instead of mapping to the same UUID.
+func ParseFlakeID(s string) (UUID, error) {
+	return (Codec{Encoding: EncodingBase64}).Decode(s)
+}
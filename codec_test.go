@@ -0,0 +1,136 @@
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCodec(t *testing.T) {
+	uuids := []UUID{New(), New(), {}, {0, 0, 1}, {
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}}
+	for _, encoding := range []Encoding{
+		EncodingCanonical, EncodingHex, EncodingBase32, EncodingBase64,
+		EncodingBase58,
+	} {
+		codec := Codec{Encoding: encoding}
+		for _, uuid := range uuids {
+			str := codec.Encode(uuid)
+			newUUID, err := codec.Decode(str)
+			if err != nil {
+				t.Error(encoding, err)
+			}
+			if !uuid.Equal(newUUID) {
+				t.Error("bad restore", encoding, str)
+			}
+		}
+		if _, err := codec.Decode("0"); err == nil {
+			t.Error("bad decode", encoding)
+		}
+		if uuid, err := codec.Decode("2"); err == nil || uuid != Nil {
+			t.Error("bad decode", encoding, uuid, err)
+		}
+	}
+
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for encoding, want := range map[Encoding]string{
+		EncodingHex:    "6ba7b8109dad11d180b400c04fd430c8",
+		EncodingBase64: "a6e4EJ2tEdGAtADAT9QwyA",
+	} {
+		if str := (Codec{Encoding: encoding}).Encode(uuid); str != want {
+			t.Error("bad encode", encoding, str)
+		}
+	}
+
+	codec := Codec{Encoding: Encoding(100)}
+	if codec.Encode(uuid) != "" {
+		t.Error("bad unknown encoding")
+	}
+	if _, err := codec.Decode(uuid.String()); err == nil {
+		t.Error("bad unknown encoding")
+	}
+	codec = Codec{Encoding: EncodingBase58}
+	if str := codec.Encode(uuid); str != "EJ34kCVxxF9jHMKD4EgrAK" {
+		t.Error("bad encode", codec.Encoding, str)
+	}
+	if _, err := codec.Decode("1" + codec.Encode(uuid)); err == nil {
+		t.Error("bad base58 decode")
+	}
+}
+
+func TestFlakeID(t *testing.T) {
+	uuid := mustParse(t, "fbff3ffe-0000-4000-8000-00000000003e")
+	id := uuid.FlakeID()
+	if id != "-_8__gAAQACAAAAAAAAAPg" {
+		t.Error("bad flake id", id)
+	}
+	for _, uuid := range []UUID{uuid, New(), {}} {
+		newUUID, err := ParseFlakeID(uuid.FlakeID())
+		if err != nil {
+			t.Error(err)
+		}
+		if !newUUID.Equal(uuid) {
+			t.Error("bad restore", uuid)
+		}
+	}
+	for _, id := range []string{"", "-_8__gAAQACAAAAAAAAAP", "+/8//gAAQACAAAAAAAAAPg",
+		"-_8__gAAQACAAAAAAAAAPh", "-_8__gAAQACAAAAAAAAAPv"} { // non-zero trailing bits
+		if _, err := ParseFlakeID(id); err == nil {
+			t.Error("bad flake id", id)
+		}
+	}
+}
+
+func TestBase64Base32(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if s := uuid.Base64(); s != "a6e4EJ2tEdGAtADAT9QwyA" {
+		t.Error("bad base64", s)
+	}
+	if s := uuid.Base32(); s != "DEKVG44XNM8X305M0304ZN1GS0" {
+		t.Error("bad base32", s)
+	}
+	for _, uuid := range []UUID{Nil, Max, New(), NewV7()} {
+		if parsed, err := ParseBase64(uuid.Base64()); err != nil || parsed != uuid {
+			t.Error("bad base64 restore", uuid, parsed, err)
+		}
+		if parsed, err := ParseBase32(uuid.Base32()); err != nil || parsed != uuid {
+			t.Error("bad base32 restore", uuid, parsed, err)
+		}
+		if parsed, err := ParseBase32(strings.ToLower(uuid.Base32())); err != nil || parsed != uuid {
+			t.Error("bad lowercase base32 restore", uuid, parsed, err)
+		}
+	}
+	for _, s := range []string{"", "a6e4EJ2tEdGAtADAT9Qwy", "a6e4EJ2tEdGAtADAT9Qwy+", uuid.String(),
+		"a6e4EJ2tEdGAtADAT9QwyB", "a6e4EJ2tEdGAtADAT9Qwy_"} {
+		if _, err := ParseBase64(s); err == nil {
+			t.Errorf("ParseBase64(%q) accepted", s)
+		}
+	}
+	for _, s := range []string{"", "DEKVG44XNM8X305M0304ZN1GS", "DEKVG44XNM8X305M0304ZN1GSU", uuid.String(),
+		"DEKVG44XNM8X305M0304ZN1GS1", "dekvg44xnm8x305m0304zn1gs7"} {
+		if _, err := ParseBase32(s); err == nil {
+			t.Errorf("ParseBase32(%q) accepted", s)
+		}
+	}
+}
+
+func TestCodecNonCanonical(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, test := range []struct {
+		encoding Encoding
+		s        string
+	}{
+		{EncodingBase64, "a6e4EJ2tEdGAtADAT9QwyB"}, // non-zero trailing bits
+		{EncodingBase64, "a6e4EJ2tEdGAtADAT9QwyP"},
+		{EncodingBase32, "DEKVG44XNM8X305M0304ZN1GS1"},
+		{EncodingBase32, "DEKVG44XNM8X305M0304ZN1GS3"},
+	} {
+		codec := Codec{Encoding: test.encoding}
+		if codec.Encode(uuid) == test.s {
+			t.Fatal("bad test", test.s)
+		}
+		if parsed, err := codec.Decode(test.s); err == nil {
+			t.Errorf("%v: non-canonical %q decoded to %v", test.encoding, test.s, parsed)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package uuid
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// ReadCSVColumn reads the CSV data from r and returns the UUIDs from the column
+// with the given name in the header row. Empty cells are skipped. Returns an
+// error if there is no such column or if a cell can't be parsed; the error
+// contains the row number, counting the header as row 1, and the value.
+func ReadCSVColumn(r io.Reader, header string) ([]UUID, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	record, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("uuid: CSV column %q not found: no header", header)
+	}
+	if err != nil {
+		return nil, err
+	}
+	column := -1
+	for i, name := range record {
+		if name == header {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		return nil, fmt.Errorf("uuid: CSV column %q not found", header)
+	}
+	var uuids []UUID
+	for row := 2; ; row++ {
+		record, err = reader.Read()
+		if err == io.EOF {
+			return uuids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if column >= len(record) || record[column] == "" {
+			continue
+		}
+		uuid, err := Parse(record[column])
+		if err != nil {
+			return nil, fmt.Errorf("uuid: CSV row %d: invalid UUID %q: %w", row, record[column], err)
+		}
+		uuids = append(uuids, uuid)
+	}
+}
@@ -0,0 +1,37 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVColumn(t *testing.T) {
+	data := "name,id\n" +
+		"first,6ba7b810-9dad-11d1-80b4-00c04fd430c8\n" +
+		"empty,\n" +
+		"second,{6ba7b811-9dad-11d1-80b4-00c04fd430c8}\n"
+	uuids, err := ReadCSVColumn(strings.NewReader(data), "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uuids) != 2 ||
+		uuids[0].String() != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" ||
+		uuids[1].String() != "6ba7b811-9dad-11d1-80b4-00c04fd430c8" {
+		t.Error("bad uuids", uuids)
+	}
+
+	if _, err := ReadCSVColumn(strings.NewReader(data), "uuid"); err == nil ||
+		!strings.Contains(err.Error(), `"uuid" not found`) {
+		t.Error("bad missing column error", err)
+	}
+	if _, err := ReadCSVColumn(strings.NewReader(""), "id"); err == nil {
+		t.Error("bad empty input")
+	}
+	data += "bad,6ba7b8109dad11d180b400c04fd430cw\n"
+	if _, err := ReadCSVColumn(strings.NewReader(data), "id"); !errors.Is(err, ErrInvalidUUID) ||
+		!strings.Contains(err.Error(), "row 5") ||
+		!strings.Contains(err.Error(), "6ba7b8109dad11d180b400c04fd430cw") {
+		t.Error("bad invalid value error", err)
+	}
+}
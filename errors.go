@@ -0,0 +1,54 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidUUID is returned when the input is not a valid UUID. The errors of
+// Parse, ParseBytes, UnmarshalText and the other text parsers, and the errors
+// of UnmarshalJSON for the invalid strings, wrap it, so it is enough to check
+// it with errors.Is to tell the malformed input from other failures.
+var ErrInvalidUUID = errors.New("uuid: invalid UUID")
+
+// Reasons of the parse errors, returned in the Err field of ParseError.
+var (
+	ErrInvalidLength    = errors.New("invalid UUID length")
+	ErrInvalidFormat    = errors.New("invalid UUID string")
+	ErrInvalidHex       = errors.New("invalid hex digit in UUID string")
+	ErrMismatchedBraces = errors.New("mismatched braces in UUID string")
+)
+
+// ErrInvalidBinaryLength is returned when the binary form of the UUID is not
+// 16 bytes long.
+var ErrInvalidBinaryLength = errors.New("UUID must be exactly 16 bytes long")
+
+// ParseError is returned when the text can't be parsed as a UUID. It wraps
+// both the reason and ErrInvalidUUID:
+//  var perr *uuid.ParseError
+//  if errors.As(err, &perr) && perr.Err == uuid.ErrInvalidLength {
+//  	// handle the truncated input
+//  }
+type ParseError struct {
+	Input string // the text which can't be parsed
+	Err   error  // the reason: one of ErrInvalidLength, ErrInvalidFormat, ErrInvalidHex or ErrMismatchedBraces
+}
+
+// Error returns the text of the error with the reason and the input.
+func (e *ParseError) Error() string {
+	if e.Err == ErrInvalidLength {
+		return fmt.Sprintf("uuid: %v %d: %s", e.Err, len(e.Input), e.Input)
+	}
+	return fmt.Sprintf("uuid: %v: %s", e.Err, e.Input)
+}
+
+// Unwrap returns the reason of the error and ErrInvalidUUID.
+func (e *ParseError) Unwrap() []error {
+	return []error{e.Err, ErrInvalidUUID}
+}
+
+// parseError returns the ParseError with the text of type string or []byte
+// and the reason.
+func parseError[T string | []byte](text T, reason error) error {
+	return &ParseError{Input: string(text), Err: reason}
+}
@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	for _, test := range []struct {
+		input  string
+		reason error
+		text   string
+	}{
+		{"6ba7b810", ErrInvalidLength, "uuid: invalid UUID length 8: 6ba7b810"},
+		{"6ba7b810-9dad-11d1-80b4x00c04fd430c8", ErrInvalidFormat,
+			"uuid: invalid UUID string: 6ba7b810-9dad-11d1-80b4x00c04fd430c8"},
+		{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430cx", ErrInvalidHex,
+			"uuid: invalid hex digit in UUID string: urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430cx"},
+		{"6ba7b8109dad11d180b400c04fd430cg", ErrInvalidHex,
+			"uuid: invalid hex digit in UUID string: 6ba7b8109dad11d180b400c04fd430cg"},
+		{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrMismatchedBraces,
+			"uuid: mismatched braces in UUID string: {6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	} {
+		for _, err := range []error{
+			func() error { _, err := Parse(test.input); return err }(),
+			func() error { _, err := ParseBytes([]byte(test.input)); return err }(),
+			new(UUID).UnmarshalText([]byte(test.input)),
+			new(UUID).UnmarshalJSON([]byte(`"` + test.input + `"`)),
+		} {
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("%q: not a ParseError: %v", test.input, err)
+			}
+			if perr.Input != test.input || perr.Err != test.reason {
+				t.Errorf("%q: bad ParseError %+v", test.input, perr)
+			}
+			if !errors.Is(err, test.reason) || !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("%q: bad wrapped errors: %v", test.input, err)
+			}
+			if err.Error() != test.text {
+				t.Errorf("%q: bad error text: %v", test.input, err)
+			}
+		}
+	}
+	err := new(UUID).UnmarshalBinary(make([]byte, 15))
+	if !errors.Is(err, ErrInvalidBinaryLength) || errors.Is(err, ErrInvalidUUID) {
+		t.Error("bad binary error", err)
+	}
+	if err.Error() != "uuid: UUID must be exactly 16 bytes long, got 15 bytes" {
+		t.Error("bad binary error text", err)
+	}
+}
+
+func TestParseErrorParsers(t *testing.T) {
+	hexCodec := Codec{Encoding: EncodingHex}
+	for _, test := range []struct {
+		name   string
+		parse  func(string) (UUID, error)
+		input  string
+		reason error
+	}{
+		{"ParseCaseSensitive", ParseCaseSensitive, "6ba7b810", ErrInvalidLength},
+		{"ParseCaseSensitive", ParseCaseSensitive, "6ba7b810-9dad-11d1-80b400-c04fd430c8", ErrInvalidFormat},
+		{"ParseCaseSensitive", ParseCaseSensitive, "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", ErrInvalidFormat},
+		{"ParseCaseSensitive", ParseCaseSensitive, "6ba7b810-9dad-11d1-80b4-00c04fd430cz", ErrInvalidHex},
+		{"ParseHexDump", ParseHexDump, "6b a7 b8 10", ErrInvalidLength},
+		{"ParseHexDump", ParseHexDump, "6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 c8 00", ErrInvalidLength},
+		{"ParseHexDump", ParseHexDump, "6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 cz", ErrInvalidHex},
+		{"Codec.Decode", hexCodec.Decode, "6ba7b810", ErrInvalidLength},
+		{"Codec.Decode", hexCodec.Decode, "6ba7b8109dad11d180b400c04fd430cz", ErrInvalidHex},
+		{"ParseStrict", ParseStrict, "6ba7b810", ErrInvalidLength},
+		{"ParseLenient", ParseLenient, " 6ba7b810-9dad-11d1-80b4-00c04fd430cz ", ErrInvalidHex},
+	} {
+		_, err := test.parse(test.input)
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Err != test.reason {
+			t.Errorf("%s(%q): bad ParseError: %v", test.name, test.input, err)
+		}
+		if !errors.Is(err, test.reason) || !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("%s(%q): bad wrapped errors: %v", test.name, test.input, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package uuid
+
+import (
+	"io"
+	"sync"
+)
+
+// Generator generates random version 4 UUIDs, reading the random bytes in
+// 4 KB chunks instead of making a read from the random source for each UUID.
+// It is safe for concurrent use. The zero value is ready to use.
+type Generator struct {
+	mu  sync.Mutex
+	buf [4096]byte
+	pos int // position of the first unused byte in buf
+	n   int // number of valid bytes in buf
+}
+
+// defaultGenerator is used by New and NewRandom.
+var defaultGenerator Generator
+
+// NewGenerator returns a new generator of random UUIDs.
+func NewGenerator() *Generator {
+	return new(Generator)
+}
+
+// New returns a new random unique identifier. It panics if the random source
+// fails.
+func (g *Generator) New() UUID {
+	uuid, err := g.newRandom()
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
+
+// newRandom returns a new random unique identifier from the buffer, refilling
+// it from the random source when exhausted. The used bytes are cleared in the
+// buffer.
+func (g *Generator) newRandom() (uuid UUID, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.n-g.pos < len(uuid) {
+		n, err := io.ReadAtLeast(randReader, g.buf[:], len(uuid))
+		if err != nil {
+			g.reset()
+			return Nil, err
+		}
+		g.pos, g.n = 0, n
+	}
+	copy(uuid[:], g.buf[g.pos:])
+	clear(g.buf[g.pos : g.pos+len(uuid)])
+	g.pos += len(uuid)
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // set version byte
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // set high order byte 0b10{8,9,a,b}
+	return uuid, nil
+}
+
+// reset drops the buffered random bytes. Must be called with the mutex held.
+func (g *Generator) reset() {
+	clear(g.buf[:g.n])
+	g.pos, g.n = 0, 0
+}
+
+// Reader is an io.Reader which endlessly produces the bytes of consecutive
+// random version 4 UUIDs, 16 bytes each. A UUID may be split between two
+// reads: the next read continues it where the previous one stopped. It is not
+// safe for concurrent use. The zero value is ready to use.
+type Reader struct {
+	off int // offset in the current UUID of the next byte to read
+}
+
+// NewReader returns a new reader of random UUID bytes.
+func NewReader() *Reader {
+	return new(Reader)
+}
+
+// Read fills p with the bytes of random UUIDs with the version and variant
+// set. Returns an error only if the random source fails.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(randReader, p)
+	for i := 0; i < n; i++ {
+		switch (r.off + i) % 16 {
+		case 6:
+			p[i] = (p[i] & 0x0f) | 0x40 // set version byte
+		case 8:
+			p[i] = (p[i] & 0x3f) | 0x80 // set high order byte 0b10{8,9,a,b}
+		}
+	}
+	r.off = (r.off + n) % 16
+	return n, err
+}
@@ -0,0 +1,85 @@
+package uuid
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestGenerator(t *testing.T) {
+	g := NewGenerator()
+	var mu sync.Mutex
+	seen := make(map[UUID]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ { // several refills of the buffer
+				uuid := g.New()
+				if !uuid.LooksLikeOurs() {
+					t.Error("bad version or variant", uuid)
+				}
+				mu.Lock()
+				if seen[uuid] {
+					t.Error("duplicate", uuid)
+				}
+				seen[uuid] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkNewUnpooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var uuid UUID
+		if _, err := io.ReadFull(rand.Reader, uuid[:]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerator(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.New()
+	}
+}
+
+func TestReader(t *testing.T) {
+	r := NewReader()
+	var data []byte
+	for _, size := range []int{7, 1, 0, 13, 33, 5, 16, 21} { // 96 bytes
+		buf := make([]byte, size)
+		n, err := r.Read(buf)
+		if err != nil || n != size {
+			t.Fatal("bad read", n, err)
+		}
+		data = append(data, buf...)
+	}
+	uuids, err := DecodeBinaryBatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uuids) != 6 {
+		t.Fatal("bad number of UUIDs", len(uuids))
+	}
+	for _, uuid := range uuids {
+		if !uuid.LooksLikeOurs() {
+			t.Error("bad version or variant", uuid)
+		}
+	}
+	var zero Reader
+	uuid, err := io.ReadAll(io.LimitReader(&zero, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := FromBytes(uuid); !u.LooksLikeOurs() {
+		t.Error("bad zero reader UUID", u)
+	}
+}
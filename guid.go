@@ -0,0 +1,39 @@
+package uuid
+
+import "fmt"
+
+// ParseGUID parses the Microsoft GUID from its string representation. The
+// string form of a GUID is always in the display order, so it is parsed
+// exactly like Parse. Use GUIDBytes to get the memory layout of the GUID.
+func ParseGUID(s string) (UUID, error) {
+	return Parse(s)
+}
+
+// GUIDBytes returns the bytes of the UUID in the mixed-endian memory layout of
+// the Microsoft GUID, as returned by .NET Guid.ToByteArray(): the first three
+// fields (4, 2 and 2 bytes) are little-endian, the remaining 8 bytes are in
+// the display order. Bytes returns the display order.
+func (u UUID) GUIDBytes() []byte {
+	return []byte{
+		u[3], u[2], u[1], u[0],
+		u[5], u[4],
+		u[7], u[6],
+		u[8], u[9], u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+}
+
+// FromGUIDBytes returns the UUID from the 16 bytes in the mixed-endian memory
+// layout of the Microsoft GUID, as accepted by .NET new Guid(byte[]). It is
+// the reverse of GUIDBytes.
+func FromGUIDBytes(b []byte) (uuid UUID, err error) {
+	if len(b) != 16 {
+		return uuid, fmt.Errorf("uuid: GUID must be exactly 16 bytes long, got %d bytes", len(b))
+	}
+	uuid = UUID{
+		b[3], b[2], b[1], b[0],
+		b[5], b[4],
+		b[7], b[6],
+		b[8], b[9], b[10], b[11], b[12], b[13], b[14], b[15],
+	}
+	return uuid, nil
+}
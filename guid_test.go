@@ -0,0 +1,33 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGUID(t *testing.T) {
+	// new Guid("00112233-4455-6677-8899-aabbccddeeff").ToByteArray() in .NET
+	netBytes := []byte{
+		0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66,
+		0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	guid, err := ParseGUID("00112233-4455-6677-8899-AABBCCDDEEFF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if guid.String() != "00112233-4455-6677-8899-aabbccddeeff" {
+		t.Error("bad display order", guid)
+	}
+	if !bytes.Equal(guid.GUIDBytes(), netBytes) {
+		t.Errorf("bad GUID bytes % x", guid.GUIDBytes())
+	}
+	newGUID, err := FromGUIDBytes(netBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !newGUID.Equal(guid) {
+		t.Error("bad restore", newGUID)
+	}
+	if _, err := FromGUIDBytes(netBytes[1:]); err == nil {
+		t.Error("bad GUID bytes length")
+	}
+}
@@ -0,0 +1,52 @@
+//go:build msgpack
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// The test requires the msgpack dependency, run it with:
+//  go test -tags msgpack
+
+func TestMsgpack(t *testing.T) {
+	uuid := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	data, err := msgpack.Marshal(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// bin 8 with the length 16 followed by the UUID bytes
+	if want := append([]byte{0xc4, 0x10}, uuid.Bytes()...); !bytes.Equal(data, want) {
+		t.Errorf("bad msgpack encoding: %x", data)
+	}
+	var restored UUID
+	if err := msgpack.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored != uuid {
+		t.Error("bad restore", restored)
+	}
+
+	type message struct {
+		ID   UUID
+		Refs []UUID
+	}
+	msg := message{ID: New(), Refs: []UUID{New(), Nil}}
+	if data, err = msgpack.Marshal(msg); err != nil {
+		t.Fatal(err)
+	}
+	var restoredMsg message
+	if err := msgpack.Unmarshal(data, &restoredMsg); err != nil {
+		t.Fatal(err)
+	}
+	if restoredMsg.ID != msg.ID || len(restoredMsg.Refs) != 2 ||
+		restoredMsg.Refs[0] != msg.Refs[0] || restoredMsg.Refs[1] != Nil {
+		t.Errorf("bad message restore: %+v", restoredMsg)
+	}
+	if err := msgpack.Unmarshal(append([]byte{0xc4, 0x0f}, make([]byte, 15)...), &restored); err == nil {
+		t.Error("bad short binary")
+	}
+}
@@ -0,0 +1,155 @@
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	namespace := New()
+	key := IdempotencyKey(namespace, "POST", "/orders", "e3b0c44298fc1c14")
+	if key.Version() != 5 || key.variantName() != "RFC4122" {
+		t.Error("bad version or variant", key)
+	}
+	if !key.Equal(IdempotencyKey(namespace, "POST", "/orders", "e3b0c44298fc1c14")) {
+		t.Error("not deterministic")
+	}
+	if key.Equal(IdempotencyKey(New(), "POST", "/orders", "e3b0c44298fc1c14")) {
+		t.Error("namespace ignored")
+	}
+	if IdempotencyKey(namespace, "a", "b").Equal(IdempotencyKey(namespace, "ab", "")) {
+		t.Error("ambiguous part boundaries")
+	}
+	if IdempotencyKey(namespace).Equal(IdempotencyKey(namespace, "")) {
+		t.Error("ambiguous empty part")
+	}
+}
+
+func TestRekey(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if key := uuid.Rekey([]byte("blue")); key.String() != "26afb253-3afe-5dcf-a5a1-df5f858dbaca" {
+		t.Error("unstable rekey", key)
+	}
+	salts := [][]byte{nil, []byte("blue"), []byte("green")}
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		uuid := New()
+		for _, salt := range salts {
+			key := uuid.Rekey(salt)
+			if key.Version() != 5 {
+				t.Fatal("bad version", key)
+			}
+			if !key.Equal(uuid.Rekey(salt)) {
+				t.Fatal("not deterministic")
+			}
+			if seen[key] {
+				t.Fatal("not injective", uuid)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestEventID(t *testing.T) {
+	aggregate := New()
+	seen := make(map[UUID]bool)
+	for sequence := uint64(0); sequence < 100; sequence++ {
+		id := EventID(aggregate, sequence)
+		if id.Version() != 5 {
+			t.Fatal("bad version", id)
+		}
+		if !id.Equal(EventID(aggregate, sequence)) {
+			t.Fatal("not deterministic", sequence)
+		}
+		if seen[id] {
+			t.Fatal("collision", sequence)
+		}
+		seen[id] = true
+		if sequence > 0 && bytes.Equal(id[:8], EventID(aggregate, sequence-1).Bytes()[:8]) {
+			t.Error("sequential ids", sequence)
+		}
+	}
+	if EventID(aggregate, 1).Equal(EventID(New(), 1)) {
+		t.Error("aggregate ignored")
+	}
+}
+
+func TestCombine(t *testing.T) {
+	a, b := New(), New()
+	key := Combine(a, b)
+	if key.Version() != 5 {
+		t.Error("bad version", key)
+	}
+	if !key.Equal(Combine(a, b)) {
+		t.Error("not deterministic")
+	}
+	if key.Equal(Combine(b, a)) {
+		t.Error("order ignored")
+	}
+	if key.Equal(Combine(a, b, a)) || Combine().Equal(Combine(UUID{})) {
+		t.Error("count ignored")
+	}
+}
+
+func TestNewV5(t *testing.T) {
+	uuid := NewV5(NamespaceDNS, []byte("www.example.com"))
+	if uuid.String() != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Error("bad v5", uuid)
+	}
+	if uuid.Version() != 5 || !uuid.IsNameBased() {
+		t.Error("bad version", uuid.Version())
+	}
+	if !uuid.Equal(NewV5(NamespaceDNS, []byte("www.example.com"))) {
+		t.Error("not deterministic")
+	}
+	if got := NewV5(NamespaceURL, []byte("https://github.com/mdigger/uuid#rekey")); !got.Equal(rekeyNamespace) {
+		t.Error("bad internal namespace", got)
+	}
+}
+
+func TestNewV3(t *testing.T) {
+	uuid := NewV3(NamespaceDNS, []byte("www.example.com"))
+	if uuid.String() != "5df41881-3aed-3515-88a7-2f4a814cf09e" {
+		t.Error("bad v3", uuid)
+	}
+	if uuid.Version() != 3 || !uuid.IsNameBased() {
+		t.Error("bad version", uuid.Version())
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	for ns, want := range map[UUID]string{
+		NamespaceDNS:  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceURL:  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceOID:  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceX500: "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+	} {
+		if ns.String() != want {
+			t.Error("bad namespace", ns, want)
+		}
+	}
+}
+
+func TestFromString(t *testing.T) {
+	if uuid := FromString("TestFoo"); uuid.String() != "f8aa64ba-dedf-5604-a8c5-91b081bfcae6" {
+		t.Error("unstable FromString", uuid)
+	}
+	if FromString(t.Name()) != FromString(t.Name()) {
+		t.Error("not deterministic")
+	}
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		uuid := FromString(fmt.Sprintf("%s/%d", t.Name(), i))
+		if uuid.Version() != 5 || uuid.Variant() != VariantRFC4122 {
+			t.Fatal("bad version or variant", uuid)
+		}
+		if seen[uuid] {
+			t.Fatal("duplicate", uuid)
+		}
+		seen[uuid] = true
+	}
+	if FromString("") == NewV5(NamespaceURL, nil) {
+		t.Error("public namespace used")
+	}
+}
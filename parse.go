@@ -0,0 +1,106 @@
+package uuid
+
+// parse decodes the text form of the UUID into dst. If dst is nil, the text
+// is only validated. The dst is not modified on error. The returned error is
+// the bare reason, so the validation does not allocate; the callers wrap it
+// with parseError.
+func parse[T string | []byte](dst *UUID, text T) error {
+	if n := len(text); n > 0 && (text[0] == '{') != (text[n-1] == '}') {
+		return ErrMismatchedBraces
+	}
+	switch len(text) {
+	case 32:
+		if !decodeHex(dst, text, false) {
+			return ErrInvalidHex
+		}
+		return nil
+	case 36:
+	case 38:
+		if text[0] != '{' || text[37] != '}' {
+			return ErrInvalidFormat
+		}
+		text = text[1:37]
+	case 45:
+		if !hasURNPrefix(text) {
+			return ErrInvalidFormat
+		}
+		text = text[9:]
+	default:
+		return ErrInvalidLength
+	}
+	// every branch above leaves exactly 36 bytes of the canonical form, so
+	// the fixed offsets below are always in range
+	if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return ErrInvalidFormat
+	}
+	if !decodeHex(dst, text, true) {
+		return ErrInvalidHex
+	}
+	return nil
+}
+
+// decodeHex decodes the 32 hex digits of the UUID into dst, if it is not nil.
+// If dashed is true, the text is in the 36-byte canonical form and the dashes
+// are skipped. Returns false if the text contains a character which is not a
+// hex digit.
+func decodeHex[T string | []byte](dst *UUID, text T, dashed bool) bool {
+	var uuid UUID
+	j := 0
+	for i := range uuid {
+		if dashed {
+			switch i {
+			case 4, 6, 8, 10:
+				j++ // skip the dash
+			}
+		}
+		hi, ok1 := fromHexChar(text[j])
+		lo, ok2 := fromHexChar(text[j+1])
+		if !ok1 || !ok2 {
+			return false
+		}
+		uuid[i] = hi<<4 | lo
+		j += 2
+	}
+	if dst != nil {
+		*dst = uuid
+	}
+	return true
+}
+
+// fromHexChar returns the value of the hex digit c in any case.
+func fromHexChar(c byte) (byte, bool) {
+	v := hexValues[c]
+	return v, v != 0xff
+}
+
+// hexValues maps the hex digits in any case to their values and all other
+// bytes to 0xff.
+var hexValues = func() (values [256]byte) {
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i := byte(0); i < 16; i++ {
+		values[hexDigits[i]] = i
+		values[upperHexDigits[i]] = i
+	}
+	return
+}()
+
+// hasURNPrefix returns true if the text starts with the "urn:uuid:" prefix in
+// any case, as the URN scheme and namespace are case-insensitive (RFC 8141).
+func hasURNPrefix[T string | []byte](text T) bool {
+	const prefix = "urn:uuid:"
+	if len(text) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := text[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,209 @@
+package uuid
+
+import "testing"
+
+func TestIsValid(t *testing.T) {
+	for str, valid := range map[string]bool{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8":          true,
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8":          true,
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}":        true,
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8": true,
+		"6ba7b8109dad11d180b400c04fd430c8":              true,
+		"":                                      false,
+		"6ba7b810":                              false,
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cw":  false,
+		"6ba7b810-9dad-11d1-80b400-c04fd430c8":  false,
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8": false,
+	} {
+		if IsValid(str) != valid {
+			t.Error("bad validation", str)
+		}
+		if n := testing.AllocsPerRun(100, func() { IsValid(str) }); n != 0 {
+			t.Error("IsValid allocates", str, n)
+		}
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		IsValid("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func TestParseCaseInsensitive(t *testing.T) {
+	const want = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	for _, str := range []string{
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6Ba7b810-9dAd-11D1-80b4-00C04fd430C8",
+		"{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}",
+		"URN:UUID:6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"Urn:Uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6BA7B8109DAD11D180B400C04FD430C8",
+	} {
+		uuid, err := Parse(str)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if uuid.String() != want {
+			t.Error("bad case-insensitive parse", str, uuid)
+		}
+		text, _ := uuid.MarshalText()
+		if string(text) != want {
+			t.Error("bad lowercase output", string(text))
+		}
+	}
+	for _, str := range []string{
+		"urn;uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"urn\x1auuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("bad urn prefix %q", str)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for _, str := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		uuid, err := ParseBytes([]byte(str))
+		if err != nil || !uuid.Equal(NamespaceDNS) {
+			t.Error("bad parse bytes", str, err)
+		}
+		var dst UUID
+		if err := ParseInto(&dst, []byte(str)); err != nil || !dst.Equal(NamespaceDNS) {
+			t.Error("bad parse into", str, err)
+		}
+	}
+	dst := NamespaceURL
+	if err := ParseInto(&dst, []byte("6ba7b810")); err == nil || !dst.Equal(NamespaceURL) {
+		t.Error("bad parse into invalid", err)
+	}
+	if _, err := ParseBytes(nil); err == nil {
+		t.Error("bad parse bytes empty")
+	}
+}
+
+func benchmarkInputs() [][]byte {
+	inputs := make([][]byte, 1000)
+	for i := range inputs {
+		inputs[i] = []byte(New().String())
+	}
+	return inputs
+}
+
+func BenchmarkParse(b *testing.B) {
+	inputs := benchmarkInputs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			if _, err := Parse(string(input)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	inputs := benchmarkInputs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	var uuid UUID
+	for i := 0; i < b.N; i++ {
+		for _, input := range inputs {
+			if err := ParseInto(&uuid, input); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// TestParseTruncated feeds every prefix and suffix of the accepted forms, with
+// the braces and the URN prefix added or removed, to the parser, which must
+// return an error instead of panicking.
+func TestParseTruncated(t *testing.T) {
+	var inputs []string
+	for _, form := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"{6ba7b8109dad11d180b400c04fd430c8}",
+		"{6ba7b8109dad11d180b400c04fd430}",
+		"urn:uuid:6ba7b8109dad11d180b400c04fd430c8",
+		"urn:uuid:{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"{{{{{{{{{{{{{{{{{{{{{{{{{{{{{{{}",
+		"urn:uuid:urn:uuid:urn:uuid:urn:uuid:urn:uuid:",
+	} {
+		for i := 0; i <= len(form); i++ {
+			inputs = append(inputs, form[:i], form[i:], "{"+form[:i]+"}")
+		}
+	}
+	for _, s := range inputs {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Parse(%q) panics: %v", s, r)
+				}
+			}()
+			uuid, err := Parse(s)
+			if err == nil && uuid.String() != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+				t.Errorf("Parse(%q) = %v", s, uuid)
+			}
+			if _, err := ParseBytes([]byte(s)); (err == nil) != IsValid(s) {
+				t.Errorf("ParseBytes(%q) and IsValid disagree", s)
+			}
+			if (ClassifyInvalid(s) == "ok") != IsValid(s) {
+				t.Errorf("ClassifyInvalid(%q) and IsValid disagree", s)
+			}
+		}()
+	}
+}
+
+// FuzzUnmarshalText checks that the parsers never panic on arbitrary input
+// and that the accepted input round-trips to the same UUID. The seed corpus
+// with the accepted forms is in testdata/fuzz/FuzzUnmarshalText.
+func FuzzUnmarshalText(f *testing.F) {
+	for _, s := range []string{
+		"",
+		"{}",
+		"urn:uuid:",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8-",
+		"{6ba7b8109dad11d180b400c04fd430}",
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var uuid UUID
+		err := uuid.UnmarshalText(data)
+		if IsValid(string(data)) != (err == nil) {
+			t.Fatalf("IsValid and UnmarshalText disagree on %q", data)
+		}
+		if (ClassifyInvalid(string(data)) == "ok") != (err == nil) {
+			t.Fatalf("ClassifyInvalid and UnmarshalText disagree on %q", data)
+		}
+		if err == nil {
+			for _, s := range []string{uuid.String(), uuid.URN(), uuid.Hex(), uuid.Format(StyleBraced)} {
+				if parsed, err := Parse(s); err != nil || parsed != uuid {
+					t.Fatalf("%q: bad round-trip of %q: %v", data, s, err)
+				}
+			}
+		}
+		// the other parsers must not panic either
+		ParseAny(string(data))
+		ParseLenient(string(data))
+		ParseCaseSensitive(string(data))
+		ParseHexDump(string(data))
+		ParseLine(data)
+		ClassifyInvalid(string(data))
+		new(UUID).UnmarshalJSON(data)
+		new(UUID).UnmarshalCBOR(data)
+		new(UUID).Scan(data)
+	})
+}
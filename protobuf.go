@@ -0,0 +1,39 @@
+package uuid
+
+import "fmt"
+
+// The methods Marshal, MarshalTo, Unmarshal and Size let the UUID be used as
+// the custom type of the bytes fields in the code generated by
+// github.com/gogo/protobuf:
+//  bytes id = 1 [(gogoproto.customtype) = "github.com/mdigger/uuid.UUID", (gogoproto.nullable) = false];
+// The field contains the 16 bytes of the UUID.
+
+// Marshal returns the 16 bytes of the UUID, like MarshalBinary.
+func (u UUID) Marshal() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// MarshalTo copies the 16 bytes of the UUID to data and returns the number of
+// bytes written. Returns an error if data is shorter than 16 bytes.
+func (u UUID) MarshalTo(data []byte) (int, error) {
+	if len(data) < len(u) {
+		return 0, fmt.Errorf("uuid: buffer of %d bytes is too short for UUID", len(data))
+	}
+	return copy(data, u[:]), nil
+}
+
+// Unmarshal decodes the UUID from the 16 bytes, like UnmarshalBinary. The
+// empty data, which is the default value of the bytes field, sets the Nil
+// UUID.
+func (u *UUID) Unmarshal(data []byte) error {
+	if len(data) == 0 {
+		*u = Nil
+		return nil
+	}
+	return u.UnmarshalBinary(data)
+}
+
+// Size returns the size of the UUID encoded by Marshal, which is always 16.
+func (u UUID) Size() int {
+	return len(u)
+}
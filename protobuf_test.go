@@ -0,0 +1,59 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+// protoMessage mimics the code generated by gogoproto for the message with the
+// non-nullable bytes field 1 of the custom type UUID.
+type protoMessage struct {
+	ID UUID
+}
+
+func (m *protoMessage) Marshal() ([]byte, error) {
+	size := m.ID.Size()
+	data := make([]byte, 2+size)
+	data[0], data[1] = 0x0a, byte(size) // field 1, wire type 2, length
+	n, err := m.ID.MarshalTo(data[2:])
+	if err != nil {
+		return nil, err
+	}
+	return data[:2+n], nil
+}
+
+func (m *protoMessage) Unmarshal(data []byte) error {
+	return m.ID.Unmarshal(data[2 : 2+int(data[1])])
+}
+
+func TestProtobuf(t *testing.T) {
+	msg := protoMessage{ID: New()}
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([]byte{0x0a, 0x10}, msg.ID.Bytes()...); !bytes.Equal(data, want) {
+		t.Errorf("bad message % x", data)
+	}
+	var restored protoMessage
+	if err := restored.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if restored.ID != msg.ID {
+		t.Error("bad restore", restored.ID)
+	}
+
+	if data, err := msg.ID.Marshal(); err != nil || !bytes.Equal(data, msg.ID.Bytes()) {
+		t.Error("bad marshal", data, err)
+	}
+	if n, err := msg.ID.MarshalTo(make([]byte, 15)); err == nil || n != 0 {
+		t.Error("bad short buffer", n, err)
+	}
+	uuid := New()
+	if err := uuid.Unmarshal(nil); err != nil || !uuid.IsNil() {
+		t.Error("bad empty field", uuid, err)
+	}
+	if err := uuid.Unmarshal(make([]byte, 17)); err == nil {
+		t.Error("bad field length")
+	}
+}
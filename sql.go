@@ -0,0 +1,161 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sync/atomic"
+)
+
+// BinaryUUID is a UUID stored in the database in the binary form. Unlike
+// UUID, its Value returns the 16 raw bytes, suitable for the BINARY(16)
+// columns in MySQL or the uuid type in PostgreSQL:
+//
+//  db.Exec("INSERT INTO items (id) VALUES (?)", uuid.BinaryUUID(id))
+//
+// The binary form takes 16 bytes instead of 36 in the table and the indexes,
+// but is not human-readable in the SQL console and requires a binary column.
+// UUID.Value keeps returning the string for compatibility.
+type BinaryUUID UUID
+
+// PGUUID is a UUID stored in the database in the binary form, which the
+// PostgreSQL binary protocol accepts for the uuid type without parsing the
+// text on the server side. It is the same type as BinaryUUID.
+type PGUUID = BinaryUUID
+
+// Value provides support for the interface driver.Valuer. Returns the 16 byte
+// representation of the UUID.
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return UUID(u).Bytes(), nil
+}
+
+// Scan provides support for the sql interface.Scanner. Accepts both the
+// binary and the text form of the UUID, like UUID.Scan.
+func (u *BinaryUUID) Scan(src interface{}) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// String returns the canonical string representation of the UUID, like
+// UUID.String.
+func (u BinaryUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText provides support for the interface encoding.TextMarshaler, like
+// UUID.MarshalText, so the BinaryUUID fields are serialized to JSON and other
+// text formats as the canonical string, not as the array of bytes.
+func (u BinaryUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText provides support for the interface encoding.TextUnmarshaler,
+// like UUID.UnmarshalText.
+func (u *BinaryUUID) UnmarshalText(text []byte) error {
+	return (*UUID)(u).UnmarshalText(text)
+}
+
+// UnmarshalJSON provides support for the interface json.Unmarshaler, like
+// UUID.UnmarshalJSON.
+func (u *BinaryUUID) UnmarshalJSON(data []byte) error {
+	return (*UUID)(u).UnmarshalJSON(data)
+}
+
+// NullUUID represents a UUID that may be SQL NULL or JSON null. It supports
+// the interfaces sql.Scanner and driver.Valuer, like sql.NullString.
+//
+// The unset UUID field is serialized to JSON as the Nil UUID string, which
+// consumers may take for a real identifier. Use NullUUID, which is serialized
+// as null when it is not valid, or a *UUID field, which is serialized as
+// null when it is nil and is omitted with the omitempty option:
+//
+//  type Item struct {
+//  	ID       uuid.UUID     `json:"id"`
+//  	ParentID uuid.NullUUID `json:"parent_id"`        // null if not valid
+//  	OwnerID  *uuid.UUID    `json:"owner_id,omitempty"` // omitted if nil
+//  }
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan provides support for the sql interface.Scanner. The nil source sets
+// Valid to false, other sources are scanned with UUID.Scan.
+func (n *NullUUID) Scan(src interface{}) error {
+	if src == nil {
+		n.UUID, n.Valid = Nil, false
+		return nil
+	}
+	if err := n.UUID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value provides support for the interface driver.Valuer. Returns nil if the
+// UUID is not valid.
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
+
+// MarshalJSON provides support for the interface json.Marshaler. Returns the
+// JSON null if the UUID is not valid.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.UUID)
+}
+
+// UnmarshalJSON provides support for the interface json.Unmarshaler. The JSON
+// null sets Valid to false.
+func (n *NullUUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.UUID, n.Valid = Nil, false
+		return nil
+	}
+	if err := n.UUID.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// ValueFormat selects the representation of the UUID returned by UUID.Value.
+type ValueFormat uint32
+
+// Supported value formats.
+const (
+	ValueFormatString ValueFormat = iota // 36-byte canonical string, the default
+	ValueFormatBinary                    // 16 raw bytes, like BinaryUUID
+)
+
+// valueFormat is the ValueFormat used by UUID.Value.
+var valueFormat atomic.Uint32
+
+// SetValueFormat sets the representation of the UUID returned by UUID.Value
+// for the whole process: the canonical string, which is the default and
+// suits lib/pq and the text columns, or the 16 bytes, which suit pgx, the
+// uuid columns of PostgreSQL and the BINARY(16) columns of MySQL. UUID.Scan
+// accepts both forms. It is safe to call concurrently, but should be called
+// once at the initialization, before the UUIDs are written, since the format
+// changes for all the drivers at once. NullUUID follows the format, while
+// BinaryUUID always returns the 16 bytes.
+func SetValueFormat(format ValueFormat) {
+	valueFormat.Store(uint32(format))
+}
+
+// Size of the UUID representations in bytes.
+const (
+	textSize   = 36 // canonical string form
+	binarySize = 16 // raw bytes
+)
+
+// StorageComparison returns the number of bytes required to store n UUIDs in
+// the canonical text form and in the binary form.
+func StorageComparison(n int) (textBytes, binaryBytes int) {
+	return n * textSize, n * binarySize
+}
@@ -0,0 +1,307 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPGUUID(t *testing.T) {
+	uuid := New()
+	value, err := PGUUID(uuid).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, ok := value.([]byte)
+	if !ok || len(data) != 16 {
+		t.Fatalf("bad value %T %v", value, value)
+	}
+	for _, src := range []interface{}{data, uuid.String(), []byte(uuid.String())} {
+		var newUUID PGUUID
+		if err := newUUID.Scan(src); err != nil {
+			t.Error(err)
+		}
+		if !uuid.Equal(UUID(newUUID)) {
+			t.Error("bad restore", src)
+		}
+	}
+}
+
+func TestPGUUIDJSON(t *testing.T) {
+	type item struct {
+		ID PGUUID `json:"id"`
+	}
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	data, err := json.Marshal(item{ID: PGUUID(uuid)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}` {
+		t.Error("bad marshal", string(data))
+	}
+	var newItem item
+	if err := json.Unmarshal(data, &newItem); err != nil || !uuid.Equal(UUID(newItem.ID)) {
+		t.Error("bad unmarshal", newItem.ID, err)
+	}
+	if str := PGUUID(uuid).String(); str != uuid.String() {
+		t.Error("bad string", str)
+	}
+}
+
+func TestStorageComparison(t *testing.T) {
+	for n, want := range map[int][2]int{
+		0:       {0, 0},
+		1:       {36, 16},
+		1000000: {36000000, 16000000},
+	} {
+		textBytes, binaryBytes := StorageComparison(n)
+		if textBytes != want[0] || binaryBytes != want[1] {
+			t.Error("bad storage comparison", n, textBytes, binaryBytes)
+		}
+	}
+	if textBytes, _ := StorageComparison(1); textBytes != len(New().String()) {
+		t.Error("bad text size")
+	}
+}
+
+func TestBinaryUUID(t *testing.T) {
+	uuid := New()
+	value, err := BinaryUUID(uuid).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, ok := value.([]byte)
+	if !ok || !bytes.Equal(data, uuid.Bytes()) {
+		t.Fatalf("bad value %T %v", value, value)
+	}
+	var newUUID BinaryUUID
+	if err := newUUID.Scan(data); err != nil || !uuid.Equal(UUID(newUUID)) {
+		t.Error("bad restore", err)
+	}
+	if value, _ := uuid.Value(); value != uuid.String() {
+		t.Error("bad default value", value)
+	}
+}
+
+func TestNullUUID(t *testing.T) {
+	var null NullUUID
+	if err := null.Scan(nil); err != nil || null.Valid {
+		t.Error("bad null scan", err)
+	}
+	if value, err := null.Value(); err != nil || value != nil {
+		t.Error("bad null value", value, err)
+	}
+	uuid := New()
+	for _, src := range []interface{}{uuid.String(), uuid.Bytes()} {
+		if err := null.Scan(src); err != nil || !null.Valid || !null.UUID.Equal(uuid) {
+			t.Error("bad scan", src, err)
+		}
+	}
+	if value, err := null.Value(); err != nil || value != uuid.String() {
+		t.Error("bad value", value, err)
+	}
+	if err := null.Scan(123); err == nil || null.Valid {
+		t.Error("bad invalid scan")
+	}
+
+	type record struct {
+		ID NullUUID `json:"id"`
+	}
+	data, err := json.Marshal(record{})
+	if err != nil || string(data) != `{"id":null}` {
+		t.Error("bad null json", string(data), err)
+	}
+	data, err = json.Marshal(record{ID: NullUUID{UUID: uuid, Valid: true}})
+	if err != nil || string(data) != `{"id":"`+uuid.String()+`"}` {
+		t.Error("bad json", string(data), err)
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil || !r.ID.Valid || !r.ID.UUID.Equal(uuid) {
+		t.Error("bad json restore", r, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":null}`), &r); err != nil || r.ID.Valid {
+		t.Error("bad null json restore", r, err)
+	}
+}
+
+// stringer is a fmt.Stringer source for Scan.
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestScan(t *testing.T) {
+	uuid := New()
+	str := uuid.String()
+	for _, src := range []interface{}{
+		str,
+		&str,
+		uuid.Bytes(),
+		[]byte(str),
+		BinaryUUID(uuid),
+		NullUUID{UUID: uuid, Valid: true},
+		stringer(str),
+	} {
+		var newUUID UUID
+		if err := newUUID.Scan(src); err != nil {
+			t.Errorf("scan %T: %v", src, err)
+		}
+		if !newUUID.Equal(uuid) {
+			t.Errorf("bad scan %T", src)
+		}
+	}
+	var nilStr *string
+	for _, src := range []interface{}{nil, nilStr, NullUUID{}, 123} {
+		var newUUID UUID
+		if err := newUUID.Scan(src); err == nil {
+			t.Errorf("bad scan %T", src)
+		}
+	}
+}
+
+func TestScanBinaryLength(t *testing.T) {
+	uuid := New()
+	for _, src := range [][]byte{
+		uuid.Bytes(),
+		[]byte(uuid.String()),
+		[]byte(uuid.Hex()),
+	} {
+		var newUUID UUID
+		if err := newUUID.Scan(src); err != nil || newUUID != uuid {
+			t.Errorf("bad scan of %d bytes: %v", len(src), err)
+		}
+	}
+	for _, src := range [][]byte{
+		uuid.Bytes()[:15],
+		append(uuid.Bytes(), 0),
+		[]byte(uuid.String())[:35],
+	} {
+		var newUUID UUID
+		err := newUUID.Scan(src)
+		if !errors.Is(err, ErrInvalidBinaryLength) {
+			t.Errorf("bad scan of %d bytes: %v", len(src), err)
+		}
+	}
+	var newUUID UUID
+	if err := newUUID.Scan([]byte(uuid.String() + "0")); errors.Is(err, ErrInvalidBinaryLength) ||
+		!errors.Is(err, ErrInvalidUUID) {
+		t.Error("bad scan of 37 bytes", err)
+	}
+}
+
+func TestScanArray(t *testing.T) {
+	uuid := New()
+	array := [16]byte(uuid)
+	for _, src := range []interface{}{array, &array, uuid, &uuid} {
+		var newUUID UUID
+		if err := newUUID.Scan(src); err != nil || newUUID != uuid {
+			t.Errorf("bad scan %T: %v", src, err)
+		}
+	}
+	var nilArray *[16]byte
+	var nilUUID *UUID
+	for _, src := range []interface{}{nilArray, nilUUID} {
+		var newUUID UUID
+		if err := newUUID.Scan(src); err == nil {
+			t.Errorf("bad scan %T", src)
+		}
+	}
+}
+
+func TestScanPadded(t *testing.T) {
+	uuid := New()
+	padded := uuid.String() + "    " // CHAR(40)
+	hex := uuid.Hex() + "    "
+	for _, src := range []interface{}{
+		padded,
+		&padded,
+		[]byte(padded),
+		[]byte(hex),
+		NullUUID{UUID: uuid, Valid: true},
+	} {
+		var newUUID UUID
+		if err := newUUID.Scan(src); err != nil || newUUID != uuid {
+			t.Errorf("bad scan %T: %v", src, err)
+		}
+	}
+	// the binary form ending with spaces is not trimmed
+	binary := UUID{0: 1, 14: ' ', 15: ' '}
+	var newUUID UUID
+	if err := newUUID.Scan(binary.Bytes()); err != nil || newUUID != binary {
+		t.Error("bad binary scan", newUUID, err)
+	}
+	if err := newUUID.Scan(append(binary.Bytes(), ' ')); !errors.Is(err, ErrInvalidBinaryLength) {
+		t.Error("bad scan of 17 bytes", err)
+	}
+	for _, src := range []interface{}{" " + uuid.String(), uuid.String() + "\t"} {
+		if err := newUUID.Scan(src); err == nil {
+			t.Errorf("bad scan %q", src)
+		}
+	}
+}
+
+func TestNullJSONPatterns(t *testing.T) {
+	type item struct {
+		ID       UUID     `json:"id"`
+		ParentID NullUUID `json:"parent_id"`
+		OwnerID  *UUID    `json:"owner_id,omitempty"`
+	}
+	data, err := json.Marshal(item{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":"00000000-0000-0000-0000-000000000000","parent_id":null}` {
+		t.Error("bad zero item", string(data))
+	}
+	owner := New()
+	data, err = json.Marshal(item{OwnerID: &owner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored item
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.OwnerID == nil || !restored.OwnerID.Equal(owner) || restored.ParentID.Valid {
+		t.Error("bad restored item", string(data))
+	}
+}
+
+func TestSetValueFormat(t *testing.T) {
+	defer SetValueFormat(ValueFormatString)
+	uuid := New()
+	for _, test := range []struct {
+		format ValueFormat
+		want   interface{}
+	}{
+		{ValueFormatBinary, uuid.Bytes()},
+		{ValueFormatString, uuid.String()},
+	} {
+		SetValueFormat(test.format)
+		value, err := uuid.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch want := test.want.(type) {
+		case []byte:
+			if data, ok := value.([]byte); !ok || !bytes.Equal(data, want) {
+				t.Errorf("bad binary value %v", value)
+			}
+		case string:
+			if value != want {
+				t.Errorf("bad string value %v", value)
+			}
+		}
+		var newUUID UUID
+		if err := newUUID.Scan(value); err != nil || newUUID != uuid {
+			t.Errorf("bad scan of format %d: %v", test.format, err)
+		}
+		// NullUUID follows the format, BinaryUUID does not depend on it
+		if value, _ := (NullUUID{UUID: uuid, Valid: true}).Value(); value == nil {
+			t.Error("bad null value")
+		}
+		if value, _ := BinaryUUID(uuid).Value(); !bytes.Equal(value.([]byte), uuid.Bytes()) {
+			t.Error("bad binary UUID value", value)
+		}
+	}
+}
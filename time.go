@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// gregorianOffset is the number of 100-nanosecond intervals between the
+// beginning of the Gregorian calendar (15 October 1582) and the Unix epoch.
+const gregorianOffset = 0x01b21dd213814000
+
+// Time returns the timestamp embedded in the time-based UUID. The second
+// returned value is false if the version of the UUID does not contain the
+// time (all versions except 1, 6 and 7).
+//
+// For versions 1 and 6 the time has a precision of 100 nanoseconds, for the
+// version 7 — a precision of one millisecond, so the time of a version 7 UUID
+// is truncated to the millisecond of its generation.
+func (u UUID) Time() (time.Time, bool) {
+	switch u.Version() {
+	case 1:
+		ts := uint64(binary.BigEndian.Uint16(u[6:8])&0x0fff)<<48 |
+			uint64(binary.BigEndian.Uint16(u[4:6]))<<32 |
+			uint64(binary.BigEndian.Uint32(u[0:4]))
+		return gregorianTime(ts), true
+	case 6:
+		ts := uint64(binary.BigEndian.Uint32(u[0:4]))<<28 |
+			uint64(binary.BigEndian.Uint16(u[4:6]))<<12 |
+			uint64(binary.BigEndian.Uint16(u[6:8])&0x0fff)
+		return gregorianTime(ts), true
+	case 7:
+		ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+			uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+		return time.UnixMilli(int64(ms)), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// gregorianTime converts the count of 100-nanosecond intervals since the
+// beginning of the Gregorian calendar to time.
+func gregorianTime(ts uint64) time.Time {
+	t := int64(ts) - gregorianOffset
+	return time.Unix(t/1e7, t%1e7*100)
+}
+
+// TimeInRange reports whether the timestamp embedded in the UUID falls within
+// the inclusive range [min, max]. The second returned value is false if the
+// UUID is not time-based; in this case the first value is always false.
+func (u UUID) TimeInRange(min, max time.Time) (bool, bool) {
+	t, ok := u.Time()
+	if !ok {
+		return false, false
+	}
+	return !t.Before(min) && !t.After(max), true
+}
+
+// DatePartition returns the timestamp embedded in the UUID formatted with the
+// time.Format layout, for example "2006-01-02". The time is always converted
+// to UTC, so the partition does not depend on the local time zone. The second
+// returned value is false if the UUID is not time-based.
+func (u UUID) DatePartition(layout string) (string, bool) {
+	t, ok := u.Time()
+	if !ok {
+		return "", false
+	}
+	return t.UTC().Format(layout), true
+}
@@ -0,0 +1,91 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	for uuidStr, want := range map[string]time.Time{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8": time.Date(1998, 2, 4, 22, 13, 53, 151182400, time.UTC),
+		"c232ab00-9414-11ec-b3c8-9f6bdeced846": time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC),
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846": time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC),
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f": time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC),
+	} {
+		ts, ok := mustParse(t, uuidStr).Time()
+		if !ok {
+			t.Error("no time", uuidStr)
+		}
+		if !ts.Equal(want) {
+			t.Error("bad time", uuidStr, ts.UTC(), want)
+		}
+	}
+	if _, ok := New().Time(); ok {
+		t.Error("bad time for v4")
+	}
+}
+
+func TestTimeInRange(t *testing.T) {
+	uuid := mustParse(t, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	ts, _ := uuid.Time()
+	for _, test := range []struct {
+		min, max time.Time
+		want     bool
+	}{
+		{ts, ts, true},
+		{ts.Add(-time.Hour), ts.Add(time.Hour), true},
+		{ts.Add(time.Millisecond), ts.Add(time.Hour), false},
+		{ts.Add(-time.Hour), ts.Add(-time.Millisecond), false},
+	} {
+		inRange, ok := uuid.TimeInRange(test.min, test.max)
+		if !ok || inRange != test.want {
+			t.Error("bad time range", test.min, test.max, inRange, ok)
+		}
+	}
+	if inRange, ok := New().TimeInRange(time.Time{}, time.Now()); inRange || ok {
+		t.Error("bad time range for v4")
+	}
+}
+
+// mustParse parses the UUID string or fails the test.
+func mustParse(t *testing.T, s string) UUID {
+	t.Helper()
+	uuid, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uuid
+}
+
+func TestDatePartition(t *testing.T) {
+	uuid := mustParse(t, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if partition, ok := uuid.DatePartition("2006-01-02/15"); !ok || partition != "2022-02-22/19" {
+		t.Error("bad partition", partition, ok)
+	}
+	if partition, ok := New().DatePartition("2006-01-02"); ok || partition != "" {
+		t.Error("bad partition for v4", partition)
+	}
+}
+
+func TestTimeRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		version   uint
+		new       func() UUID
+		tolerance time.Duration
+	}{
+		{1, NewV1, time.Microsecond},
+		{7, NewV7, 5 * time.Millisecond}, // the counter overflow may run ahead
+	} {
+		before := time.Now()
+		uuid := test.new()
+		after := time.Now()
+		ts, ok := uuid.Time()
+		if !ok {
+			t.Error("no time", test.version)
+			continue
+		}
+		if ts.Before(before.Add(-test.tolerance)) || ts.After(after.Add(test.tolerance)) {
+			t.Error("bad time", test.version, before, ts, after)
+		}
+	}
+}
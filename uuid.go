@@ -3,7 +3,8 @@
 //
 // The main difference from other similar packages:
 //
-// 1. support only versions of UUID V4
+// 1. support of random UUID V4, time-based UUID V1 and V7 and name-based UUID
+// V3 and V5 (RFC 4122 and RFC 9562)
 //
 // 2. full support for serialization/deserialization to text and binary form,
 // including JSON, BSON, XML and databases.
@@ -12,26 +13,158 @@ package uuid
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/subtle"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
 
 	"github.com/globalsign/mgo/bson"
 )
 
 // UUID describes the format of the unique identifier corresponding to RFC 4122.
+//
+// UUID is a value type: an array of 16 bytes, which is copied on assignment
+// and is safe to compare with == and to use as a map key. All the methods
+// which only read the UUID have value receivers and never change the caller's
+// copy; the pointer receivers are used only by the methods decoding or
+// modifying the UUID in place, such as UnmarshalText, Scan and SetVersion.
 type UUID [16]byte
 
-// New returns a new random unique identifier.
-func New() (uuid UUID) {
-	if _, err := io.ReadFull(rand.Reader, uuid[:]); err != nil {
+// Clone returns a copy of the UUID. It is the same as the assignment and
+// exists for the symmetry with the libraries whose identifiers are pointers
+// or slices.
+func (u UUID) Clone() UUID {
+	return u
+}
+
+// Nil is the special UUID with all bits set to zero.
+var Nil UUID
+
+// IsNil returns true if all bits of the UUID are zero.
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// randReader is the source of random bytes for all generated UUIDs.
+var randReader = rand.Reader
+
+// SetRand sets the source of random bytes used to generate UUIDs. A nil r
+// restores the default crypto/rand.Reader. The version and variant bits are
+// still set after reading from r. The random bytes already buffered by the
+// default generator are dropped, but not those of the generators created by
+// NewGenerator.
+//
+// SetRand is intended only for tests which need reproducible UUIDs: a
+// predictable source makes the UUIDs predictable. It is not safe to call
+// SetRand concurrently with the generation of UUIDs.
+func SetRand(r io.Reader) {
+	if r == nil {
+		r = rand.Reader
+	}
+	randReader = r
+	defaultGenerator.mu.Lock()
+	defaultGenerator.reset()
+	defaultGenerator.mu.Unlock()
+}
+
+// Max is the special UUID with all bits set to one, defined in RFC 9562
+// section 5.10. It may be used as the exclusive upper bound of a range.
+var Max = UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// IsMax returns true if all bits of the UUID are one.
+func (u UUID) IsMax() bool {
+	return u == Max
+}
+
+// New returns a new random unique identifier. It panics if the random source
+// fails; use NewRandom to handle such an error.
+func New() UUID {
+	uuid, err := NewRandom()
+	if err != nil {
 		panic(err)
 	}
-	uuid[6] = (uuid[6] & 0x0f) | 0x40 // set version byte
-	uuid[8] = (uuid[8] & 0x3f) | 0x80 // set high order byte 0b10{8,9,a,b}
-	return
+	return uuid
+}
+
+// NewRandom returns a new random unique identifier, like New, but returns the
+// error of the random source instead of panicking. The failure of the default
+// crypto/rand source is extremely rare, so New is usually more convenient,
+// while NewRandom lets long-running services log the error and retry.
+//
+// The random bytes are read in chunks by the default Generator.
+func NewRandom() (UUID, error) {
+	return defaultGenerator.newRandom()
+}
+
+// NewInto fills all the elements of dst with new random unique identifiers,
+// reading the random bytes for all of them in one call. If the read fails,
+// dst is zeroed and the error is returned. Nothing is allocated, so dst may be
+// reused for the next batch.
+func NewInto(dst []UUID) error {
+	if len(dst) == 0 {
+		return nil
+	}
+	buf := unsafe.Slice(&dst[0][0], 16*len(dst))
+	if _, err := io.ReadFull(randReader, buf); err != nil {
+		clear(dst)
+		return err
+	}
+	for i := range dst {
+		dst[i][6] = (dst[i][6] & 0x0f) | 0x40 // set version byte
+		dst[i][8] = (dst[i][8] & 0x3f) | 0x80 // set high order byte 0b10{8,9,a,b}
+	}
+	return nil
+}
+
+// NewBatch returns n new random unique identifiers, reading the random bytes
+// for all of them in one call, which is much cheaper than n calls of New. The
+// UUIDs are still independent version 4 UUIDs: each one has its own 122
+// random bits. Like New, it panics if the random source fails; use NewInto to
+// handle such an error.
+func NewBatch(n int) []UUID {
+	uuids := make([]UUID, n)
+	if err := NewInto(uuids); err != nil {
+		panic(err)
+	}
+	return uuids
+}
+
+// Must returns the UUID if err is nil and panics otherwise. It wraps the calls
+// of the functions returning a UUID and an error:
+//  var id = uuid.Must(uuid.NewRandom())
+func Must(uuid UUID, err error) UUID {
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
+
+// NewInRange returns a uniformly distributed random UUID in the range
+// [lo, hi), treating the UUIDs as 128-bit big-endian unsigned integers. The
+// version and variant are not set, so they are controlled only by the bounds.
+// Returns an error if lo is not less than hi.
+func NewInRange(lo, hi UUID) (uuid UUID, err error) {
+	low, high := new(big.Int).SetBytes(lo[:]), new(big.Int).SetBytes(hi[:])
+	if low.Cmp(high) >= 0 {
+		return uuid, fmt.Errorf("uuid: invalid range [%s, %s)", lo, hi)
+	}
+	n, err := rand.Int(randReader, high.Sub(high, low))
+	if err != nil {
+		return uuid, err
+	}
+	n.Add(n, low).FillBytes(uuid[:])
+	return uuid, nil
 }
 
 // Equal returns true if the UUID is equal to the current compare.
@@ -39,103 +172,968 @@ func (u UUID) Equal(uuid UUID) bool {
 	return bytes.Equal(u[:], uuid[:])
 }
 
+// SecureEqual returns true if the UUID is equal to the other UUID, like Equal,
+// but compares them in constant time. Use it for UUIDs used as secret tokens;
+// Equal is not constant-time.
+func (u UUID) SecureEqual(uuid UUID) bool {
+	return subtle.ConstantTimeCompare(u[:], uuid[:]) == 1
+}
+
+// Compare returns -1, 0 or +1 if the UUID is less than, equal to or greater
+// than the other UUID in the lexicographic order of bytes. It can be used
+// with slices.SortFunc; the version 7 UUIDs sort in the order of generation.
+func (u UUID) Compare(other UUID) int {
+	return bytes.Compare(u[:], other[:])
+}
+
+// Before returns true if the UUID is less than the other UUID, like
+// Compare(other) < 0:
+//  if id.Before(cutoff) {
+// The order is lexicographic, so it matches the order of generation only for
+// the version 6 and 7 UUIDs, which start with the timestamp; the version 1
+// UUIDs start with the low bits of the time, and the version 4 UUIDs are
+// random.
+func (u UUID) Before(other UUID) bool {
+	return u.Compare(other) < 0
+}
+
+// After returns true if the UUID is greater than the other UUID, like
+// Compare(other) > 0. The order is lexicographic, as for Before.
+func (u UUID) After(other UUID) bool {
+	return u.Compare(other) > 0
+}
+
+// Hash returns the 64-bit FNV-1a hash of the 16 bytes of the UUID with the
+// final bit mixing of MurmurHash3, so even the sequential UUIDs spread evenly
+// over the buckets:
+//  shard := id.Hash() % uint64(len(shards))
+// The hash is fast and stable within the process, but it is not
+// cryptographically secure and may change in the future versions of the
+// package, so it must not be persisted.
+func (u UUID) Hash() uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for _, b := range u {
+		h ^= uint64(b)
+		h *= prime64
+	}
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// EqualStrings returns true if both strings are valid UUIDs in any of the
+// forms accepted by Parse and represent the same UUID. Returns false if either
+// string can't be parsed. It is the safe way to compare UUID strings which may
+// differ in case or form.
+func EqualStrings(a, b string) bool {
+	uuidA, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	uuidB, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return uuidA.Equal(uuidB)
+}
+
 // Version returns the version of the algorithm used to generate the UUID.
 func (u UUID) Version() uint {
 	return uint(u[6] >> 4)
 }
 
+// UUID variants returned by Variant.
+const (
+	VariantNCS       byte = iota // 0b0xx, reserved for NCS backward compatibility
+	VariantRFC4122               // 0b10x, defined in RFC 4122 and RFC 9562
+	VariantMicrosoft             // 0b110, reserved for Microsoft backward compatibility
+	VariantFuture                // 0b111, reserved for future definition
+)
+
+// Variant returns the variant of the UUID layout decoded from the high bits
+// of the byte 8.
+func (u UUID) Variant() byte {
+	switch {
+	case u[8]&0x80 == 0x00:
+		return VariantNCS
+	case u[8]&0xc0 == 0x80:
+		return VariantRFC4122
+	case u[8]&0xe0 == 0xc0:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
+	}
+}
+
+// SetVersion sets the version of the UUID to the low 4 bits of v, keeping
+// the other bits of the byte 6. It lets the tests craft UUIDs of a specific
+// version from arbitrary bytes.
+func (u *UUID) SetVersion(v byte) {
+	u[6] = (u[6] & 0x0f) | v<<4 // set version byte
+}
+
+// SetVariant sets the RFC 4122 variant of the UUID, keeping the other bits of
+// the byte 8.
+func (u *UUID) SetVariant() {
+	u[8] = (u[8] & 0x3f) | 0x80 // set high order byte 0b10{8,9,a,b}
+}
+
+// LooksLikeOurs returns true if the UUID has the form generated by New: the
+// version 4 and the RFC 4122 variant. It checks only 6 fixed bits, so any
+// version 4 UUID generated elsewhere also passes, as does 1 of 64 arbitrary
+// 16-byte values.
+func (u UUID) LooksLikeOurs() bool {
+	return u.Version() == 4 && u.Variant() == VariantRFC4122
+}
+
+// IsNameBased returns true if the UUID is generated from a namespace and a
+// name: version 3 (MD5) or version 5 (SHA-1) in RFC 9562. Such UUIDs can be
+// recomputed from the same inputs.
+func (u UUID) IsNameBased() bool {
+	switch u.Version() {
+	case 3, 5:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTimeBased returns true if the UUID contains the timestamp: version 1
+// (Gregorian time), version 6 (reordered Gregorian time) or version 7 (Unix
+// time) in RFC 9562.
+func (u UUID) IsTimeBased() bool {
+	switch u.Version() {
+	case 1, 6, 7:
+		return true
+	default:
+		return false
+	}
+}
+
+// GroupByVersion splits the UUIDs into groups by the version number. The order
+// of the UUIDs within each group is preserved. The nil (version 0) and max
+// (version 15) UUIDs form their own groups. Returns an empty map for the empty
+// input.
+func GroupByVersion(uuids []UUID) map[uint][]UUID {
+	groups := make(map[uint][]UUID)
+	for _, uuid := range uuids {
+		version := uuid.Version()
+		groups[version] = append(groups[version], uuid)
+	}
+	return groups
+}
+
 // Bytes returns a byte representation of the UUID.
 func (u UUID) Bytes() []byte {
 	return u[:]
 }
 
+// AppendCanonicalArray writes the canonical string representation of the
+// UUID to the array dst without any allocation. The written bytes are equal
+// to String.
+func (u UUID) AppendCanonicalArray(dst *[36]byte) {
+	encodeCanonical(dst[:], u)
+}
+
+// WriteCanonical writes the canonical string representation of the UUID to w
+// in one call and returns the number of bytes written. The written bytes are
+// equal to String, but no intermediate string is created.
+func (u UUID) WriteCanonical(w io.Writer) (int, error) {
+	buf := canonicalPool.Get().(*[36]byte)
+	encodeCanonical(buf[:], u)
+	n, err := w.Write(buf[:])
+	canonicalPool.Put(buf)
+	return n, err
+}
+
+// WriteTo provides support for the interface io.WriterTo. It writes the
+// canonical string representation of the UUID to w, like WriteCanonical, so
+// io.Copy and the log buffers get the UUID without any allocation.
+func (u UUID) WriteTo(w io.Writer) (int64, error) {
+	n, err := u.WriteCanonical(w)
+	return int64(n), err
+}
+
+// canonicalPool holds the buffers for the canonical string representation
+// written by WriteCanonical. The buffer passed to io.Writer escapes to the
+// heap, so the pool saves an allocation for every write.
+var canonicalPool = sync.Pool{
+	New: func() interface{} { return new([36]byte) },
+}
+
+// Hex returns the UUID as 32 lowercase hex digits without dashes:
+//  6ba7b8109dad11d180b400c04fd430c8
+// Parse accepts this form.
+func (u UUID) Hex() string {
+	var buf [32]byte
+	encodeHex(buf[:], u, hexDigits)
+	return string(buf[:])
+}
+
+// OracleRaw returns the UUID as 32 uppercase hex digits without dashes, like
+// the Oracle RAWTOHEX function renders the RAW(16) column:
+//  6BA7B8109DAD11D180B400C04FD430C8
+// It is the uppercase form of Hex. Parse accepts this form.
+func (u UUID) OracleRaw() string {
+	var buf [32]byte
+	encodeHex(buf[:], u, upperHexDigits)
+	return string(buf[:])
+}
+
+// encodeHex writes the 32 hex digits of the UUID without dashes to dst using
+// the digits alphabet.
+func encodeHex(dst []byte, u UUID, digits string) {
+	for i, b := range u {
+		dst[i*2] = digits[b>>4]
+		dst[i*2+1] = digits[b&0x0f]
+	}
+}
+
+// Hex digits used for encoding.
+const (
+	hexDigits      = "0123456789abcdef"
+	upperHexDigits = "0123456789ABCDEF"
+)
+
+// encodeCanonical writes the canonical string representation of the UUID to
+// dst, which must be at least 36 bytes long.
+func encodeCanonical(dst []byte, u UUID) {
+	encodeDashed(dst, u, hexDigits)
+}
+
+// encodeDashed writes the UUID as the hex digits from the alphabet digits
+// separated by dashes in the canonical positions to dst, which must be at
+// least 36 bytes long.
+func encodeDashed(dst []byte, u UUID, digits string) {
+	_ = dst[35] // bounds check hint
+	var j int
+	for i, b := range u {
+		switch i {
+		case 4, 6, 8, 10:
+			dst[j] = '-'
+			j++
+		}
+		dst[j] = digits[b>>4]
+		dst[j+1] = digits[b&0x0f]
+		j += 2
+	}
+}
+
+// Int64Pair returns the UUID as a pair of signed 64-bit integers: hi is the
+// first 8 bytes and lo is the last 8 bytes, both in big-endian order. The
+// bits are reinterpreted as two's complement, so a half with the high bit set
+// is returned as a negative number. Use FromInt64Pair to restore the UUID.
+func (u UUID) Int64Pair() (hi, lo int64) {
+	return int64(binary.BigEndian.Uint64(u[:8])), int64(binary.BigEndian.Uint64(u[8:]))
+}
+
+// FromInt64Pair returns the UUID from a pair of signed 64-bit integers
+// returned by Int64Pair.
+func FromInt64Pair(hi, lo int64) (uuid UUID) {
+	binary.BigEndian.PutUint64(uuid[:8], uint64(hi))
+	binary.BigEndian.PutUint64(uuid[8:], uint64(lo))
+	return
+}
+
 // String returns the canonical string representation of a UUID:
 //  xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
 func (u UUID) String() string {
-	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+	var buf [36]byte
+	encodeCanonical(buf[:], u)
+	return string(buf[:])
+}
+
+// Style selects the string representation of the UUID returned by Format.
+type Style uint8
+
+// Supported styles of Format.
+const (
+	StyleCanonical Style = iota // 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+	StyleUpper                  // 6BA7B810-9DAD-11D1-80B4-00C04FD430C8
+	StyleBraced                 // {6ba7b810-9dad-11d1-80b4-00c04fd430c8}
+	StyleURN                    // urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8
+)
+
+// Format returns the string representation of the UUID in the given style.
+// An unknown style returns the canonical form, like String. All the styles
+// are accepted by Parse.
+func (u UUID) Format(style Style) string {
+	switch style {
+	case StyleUpper:
+		var buf [36]byte
+		encodeDashed(buf[:], u, upperHexDigits)
+		return string(buf[:])
+	case StyleBraced:
+		var buf [38]byte
+		buf[0], buf[37] = '{', '}'
+		encodeCanonical(buf[1:], u)
+		return string(buf[:])
+	case StyleURN:
+		var buf [45]byte
+		copy(buf[:], "urn:uuid:")
+		encodeCanonical(buf[9:], u)
+		return string(buf[:])
+	default:
+		return u.String()
+	}
+}
+
+// URN returns the UUID as the URN of the "uuid" namespace (RFC 4122 section 3):
+//  urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8
+func (u UUID) URN() string {
+	return u.Format(StyleURN)
+}
+
+// Append appends the canonical string representation of the UUID to dst and
+// returns the extended slice.
+func (u UUID) Append(dst []byte) []byte {
+	n := len(dst)
+	if n+36 > cap(dst) {
+		buf := make([]byte, n, n+36)
+		copy(buf, dst)
+		dst = buf
+	}
+	dst = dst[:n+36]
+	encodeCanonical(dst[n:], u)
+	return dst
+}
+
+// AppendText provides support for the interface encoding.TextAppender. It
+// appends the canonical string representation, like Append and MarshalText.
+func (u UUID) AppendText(b []byte) ([]byte, error) {
+	return u.Append(b), nil
+}
+
+// Short returns the first 8 hex digits of the UUID for the display in logs,
+// like the short hashes of git:
+//  6ba7b810
+// It is a display form only and can't be parsed back. The 8 digits hold 32
+// bits, so in a set of 10000 random UUIDs two of them share the short form
+// with the probability of about 1%; use ShortN for longer prefixes. The
+// short forms of the version 7 UUIDs generated close in time are the same,
+// since they start with the timestamp.
+func (u UUID) Short() string {
+	return u.ShortN(8)
+}
+
+// ShortN returns the first n hex digits of the UUID, like Short. Panics if n
+// is not in the range from 1 to 32.
+func (u UUID) ShortN(n int) string {
+	if n < 1 || n > 32 {
+		panic(fmt.Sprintf("uuid: invalid short length %d", n))
+	}
+	var buf [32]byte
+	encodeHex(buf[:], u, hexDigits)
+	return string(buf[:n])
+}
+
+// Path returns the slash-separated path for storing a file named by the UUID
+// in the fan-out directories. The path consists of levels directory names,
+// each of width hex digits taken successively from the start of the hex form
+// of the UUID, followed by the file name with all 32 hex digits:
+//  u.Path(2, 2) // "6b/a7/6ba7b8109dad11d180b400c04fd430c8"
+// Panics if levels or width is negative, if width is zero while levels is
+// not, or if levels*width exceeds 32.
+func (u UUID) Path(levels, width int) string {
+	if levels < 0 || width < 0 || levels > 0 && (width < 1 || levels > 32/width) {
+		panic(fmt.Sprintf("uuid: invalid path levels %d or width %d", levels, width))
+	}
+	name := hex.EncodeToString(u[:])
+	path := make([]byte, 0, levels*(width+1)+len(name))
+	for i := 0; i < levels; i++ {
+		path = append(path, name[i*width:(i+1)*width]...)
+		path = append(path, '/')
+	}
+	return string(append(path, name...))
+}
+
+// Pretty returns the multi-line human-friendly description of the UUID with
+// its canonical form, version, variant and, for time-based versions, the
+// embedded time in UTC:
+//  UUID:    017f22e2-79b0-7cc3-98c4-dc0c0c07398f
+//  Version: 7
+//  Variant: RFC4122
+//  Time:    2022-02-22T19:22:22Z
+// Unlike String, the result is not intended for parsing.
+func (u UUID) Pretty() string {
+	str := fmt.Sprintf("UUID:    %s\nVersion: %d\nVariant: %s", u, u.Version(), u.variantName())
+	if t, ok := u.Time(); ok {
+		str += "\nTime:    " + t.UTC().Format(time.RFC3339Nano)
+	}
+	return str
+}
+
+// Info contains the metadata of the UUID returned by UUID.Info.
+type Info struct {
+	Canonical string     `json:"canonical"`
+	Version   uint       `json:"version"`
+	Variant   string     `json:"variant"`
+	Time      *time.Time `json:"time,omitempty"` // time-based versions only
+	Node      string     `json:"node,omitempty"` // versions 1 and 6 only
+}
+
+// Info returns the metadata of the UUID. Time is set for the time-based UUIDs
+// (versions 1, 6 and 7) and is in UTC. Node is set for versions 1 and 6 as six
+// colon-separated hex bytes. For version 4 both are empty.
+func (u UUID) Info() Info {
+	info := Info{
+		Canonical: u.String(),
+		Version:   u.Version(),
+		Variant:   u.variantName(),
+	}
+	if t, ok := u.Time(); ok {
+		t = t.UTC()
+		info.Time = &t
+	}
+	if node := u.Node(); node != nil {
+		info.Node = fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x",
+			node[0], node[1], node[2], node[3], node[4], node[5])
+	}
+	return info
+}
+
+// variantName returns the name of the UUID variant.
+func (u UUID) variantName() string {
+	switch u.Variant() {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC4122:
+		return "RFC4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	default:
+		return "Future"
+	}
 }
 
 // MarshalText provides the HMDI supports the interface encoding.TextMarshaler.
 // The result of the encoding corresponds exactly to the canonical string
 // representation.
 func (u UUID) MarshalText() ([]byte, error) {
-	return []byte(u.String()), nil
+	return u.Append(make([]byte, 0, 36)), nil
 }
 
 // UnmarshalText provides support for the interface encoding.TextUnmarshaler.
 // The following formats are supported:
 //  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 //  "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
-//  "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"
-func (u *UUID) UnmarshalText(text []byte) (err error) {
-	if len(text) < 32 {
-		return fmt.Errorf("uuid: invalid UUID string: %s", text)
-	}
-	if bytes.Equal(text[:9], []byte("urn:uuid:")) {
-		text = text[9:]
-	} else if text[0] == '{' {
-		text = text[1:]
-	}
-	b := u[:]
-	for _, byteGroup := range []int{8, 4, 4, 4, 12} {
-		if text[0] == '-' {
-			text = text[1:]
-		}
-		if _, err = hex.Decode(b[:byteGroup/2], text[:byteGroup]); err != nil {
+//  "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+//  "6ba7b8109dad11d180b400c04fd430c8"
+// The length of the text must exactly match one of the formats. The braced
+// format requires both the opening and the closing brace. Parsing is
+// case-insensitive, including the "urn:uuid:" prefix, while the output of
+// String and MarshalText is always lowercase.
+func (u *UUID) UnmarshalText(text []byte) error {
+	if err := parse(u, text); err != nil {
+		return parseError(text, err)
+	}
+	return nil
+}
+
+// UnmarshalJSON provides support for the interface json.Unmarshaler. The JSON
+// string is parsed in place after stripping the quotes and does not allocate;
+// only the strings containing escape sequences are decoded first. An empty or
+// whitespace-only string returns an error wrapping ErrInvalidUUID, other JSON
+// values than strings are rejected. The JSON null sets the Nil UUID.
+//
+// Note that encoding/json scans the value once more before calling any
+// json.Unmarshaler, so inside json.Unmarshal this method is not faster than
+// UnmarshalText; the gain is for the decoders passing the raw token directly.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*u = Nil
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("uuid: cannot unmarshal JSON %s into UUID: not a string", data)
+	}
+	text := data[1 : len(data)-1]
+	if bytes.IndexByte(text, '\\') >= 0 {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
 			return err
 		}
-		text = text[byteGroup:]
-		b = b[byteGroup/2:]
+		text = []byte(str)
 	}
-	return
+	if len(bytes.TrimSpace(text)) == 0 {
+		return fmt.Errorf("%w: empty string", ErrInvalidUUID)
+	}
+	return u.UnmarshalText(text)
 }
 
 // MarshalBinary provides the HMDI supports the interface
 // encoding.BinaryMarshaler.
+//
+// MessagePack encoders which honor encoding.BinaryMarshaler, such as
+// github.com/vmihailenco/msgpack, use it before encoding.TextMarshaler, so
+// the UUID is written as the bin 8 type with 16 bytes of data, 18 bytes in
+// total instead of 38 for the string, and is decoded with UnmarshalBinary.
 func (u UUID) MarshalBinary() (data []byte, err error) {
-	return u.Bytes(), nil
+	return u.AppendBinary(make([]byte, 0, 16))
+}
+
+// AppendBinary provides support for the interface encoding.BinaryAppender. It
+// appends the 16 bytes of the UUID to b, like MarshalBinary.
+func (u UUID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, u[:]...), nil
 }
 
 // UnmarshalBinary provides support for the interface encoding.BinaryUnmarshaler.
 // Returns an error if data size is not equal to 16 bytes.
 func (u *UUID) UnmarshalBinary(data []byte) error {
 	if len(data) != 16 {
-		return fmt.Errorf("uuid: UUID must be exactly 16 bytes long, got %d bytes", len(data))
+		return fmt.Errorf("uuid: %w, got %d bytes", ErrInvalidBinaryLength, len(data))
 	}
 	copy(u[:], data)
 	return nil
 }
 
-// Value provides support for the interface driver.Valuer.
+// UnmarshalBinaryStrict is like UnmarshalBinary, but also returns an error if
+// the variant bits of the 16 bytes are not RFC 4122 or the version is not in
+// the range 1 to 8, so a corrupted blob is not accepted as a UUID. The UUID
+// is not modified on error.
+func (u *UUID) UnmarshalBinaryStrict(data []byte) error {
+	var uuid UUID
+	if err := uuid.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	if err := uuid.checkLayout(); err != nil {
+		return err
+	}
+	*u = uuid
+	return nil
+}
+
+// GobEncode provides support for the interface gob.GobEncoder. The UUID is
+// encoded as the 16 raw bytes, so it can be used in the net/rpc arguments and
+// replies without registration.
+func (u UUID) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode provides support for the interface gob.GobDecoder.
+func (u *UUID) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
+// FromBytes returns the UUID from the 16-byte slice. Returns an error if the
+// length of b is not 16 bytes.
+func FromBytes(b []byte) (uuid UUID, err error) {
+	err = uuid.UnmarshalBinary(b)
+	return uuid, err
+}
+
+// FromBytesOrNil returns the UUID from the 16-byte slice, or Nil if the length
+// of b is not 16 bytes.
+func FromBytesOrNil(b []byte) UUID {
+	uuid, err := FromBytes(b)
+	if err != nil {
+		return Nil
+	}
+	return uuid
+}
+
+// Value provides support for the interface driver.Valuer. Returns the
+// canonical string, or the 16 bytes if set by SetValueFormat.
 func (u UUID) Value() (driver.Value, error) {
+	if ValueFormat(valueFormat.Load()) == ValueFormatBinary {
+		return u.Bytes(), nil
+	}
 	return u.String(), nil
 }
 
 // Scan provides support for the sql interface.Scanner.
 // For the 16 byte sequence is used UnmarshalBinary, whereas the longer
-// sequence, or string is used UnmarshalText.
+// sequence, or string is used UnmarshalText. The trailing spaces of the text,
+// added by the CHAR columns, are ignored; the binary form is never trimmed.
+// The byte sequence of another length shorter than the 36-byte canonical
+// form, except the 32 hex digits, is neither binary nor text, for example a
+// truncated binary column, and returns an error wrapping
+// ErrInvalidBinaryLength. The [16]byte arrays returned by some drivers, such
+// as pgx, and UUID values are copied as is. The *string, *[16]byte, *UUID,
+// driver.Valuer and fmt.Stringer sources are also accepted. The NULL value
+// returns an error rather than silently setting the Nil UUID: use NullUUID
+// for nullable columns.
 func (u *UUID) Scan(src interface{}) error {
 	switch src := src.(type) {
+	case nil:
+		return errors.New("uuid: cannot scan NULL into UUID, use NullUUID")
 	case []byte:
 		if len(src) == 16 {
 			return u.UnmarshalBinary(src)
 		}
-		return u.UnmarshalText(src)
+		text := bytes.TrimRight(src, " ")
+		if n := len(text); n > 0 && n < 36 && n != 32 {
+			return fmt.Errorf("uuid: cannot scan %d bytes into UUID: %w or a text form", len(src), ErrInvalidBinaryLength)
+		}
+		return u.UnmarshalText(text)
 	case string:
-		return u.UnmarshalText([]byte(src))
+		return u.scanString(src)
+	case *string:
+		if src == nil {
+			return errors.New("uuid: cannot scan NULL into UUID, use NullUUID")
+		}
+		return u.scanString(*src)
+	case [16]byte:
+		*u = src
+	case *[16]byte:
+		if src == nil {
+			return errors.New("uuid: cannot scan NULL into UUID, use NullUUID")
+		}
+		*u = *src
+	case UUID:
+		*u = src
+	case *UUID:
+		if src == nil {
+			return errors.New("uuid: cannot scan NULL into UUID, use NullUUID")
+		}
+		*u = *src
+	case driver.Valuer:
+		value, err := src.Value()
+		if err != nil {
+			return err
+		}
+		if _, ok := value.(driver.Valuer); ok {
+			return fmt.Errorf("uuid: cannot convert %T to UUID", src)
+		}
+		return u.Scan(value)
+	case fmt.Stringer:
+		return u.scanString(src.String())
 	default:
 		return fmt.Errorf("uuid: cannot convert %T to UUID", src)
 	}
+	return nil
+}
+
+// scanString parses the string value of Scan, ignoring the trailing spaces of
+// the fixed-width CHAR columns.
+func (u *UUID) scanString(s string) error {
+	s = strings.TrimRight(s, " ")
+	if err := parse(u, s); err != nil {
+		return parseError(s, err)
+	}
+	return nil
 }
 
 // Parse parses and returns a UUID from its string representation.
 func Parse(s string) (uuid UUID, err error) {
-	err = uuid.UnmarshalText([]byte(s))
-	return
+	if err = parse(&uuid, s); err != nil {
+		return uuid, parseError(s, err)
+	}
+	return uuid, nil
+}
+
+// ParseBytes is like Parse, but parses the byte slice without converting it
+// to a string.
+func ParseBytes(b []byte) (uuid UUID, err error) {
+	if err = parse(&uuid, b); err != nil {
+		return uuid, parseError(b, err)
+	}
+	return uuid, nil
+}
+
+// ParseLine parses the UUID from the line of text, such as the line returned
+// by bufio.Scanner or bufio.Reader, without converting it to a string. The
+// trailing "\n" and "\r" characters are ignored. Returns skip set to true
+// and no error for the blank line, so the files with one UUID per line can be
+// read like this:
+//  for scanner.Scan() {
+//  	id, skip, err := uuid.ParseLine(scanner.Bytes())
+//  	if skip {
+//  		continue
+//  	}
+//  	...
+//  }
+func ParseLine(line []byte) (uuid UUID, skip bool, err error) {
+	for n := len(line); n > 0 && (line[n-1] == '\n' || line[n-1] == '\r'); n-- {
+		line = line[:n-1]
+	}
+	if len(line) == 0 {
+		return uuid, true, nil
+	}
+	uuid, err = ParseBytes(line)
+	return uuid, false, err
+}
+
+// ParseInto parses the byte slice like ParseBytes and writes the UUID to dst,
+// which is not modified on error. It avoids copying the result in loops.
+func ParseInto(dst *UUID, b []byte) error {
+	if err := parse(dst, b); err != nil {
+		return parseError(b, err)
+	}
+	return nil
+}
+
+// IsValid reports whether the string is a well-formed UUID in any of the
+// forms accepted by Parse. The string is only validated, not decoded.
+func IsValid(s string) bool {
+	return parse(nil, s) == nil
+}
+
+// ParseStrict is like Parse, but also rejects the parsed UUID if Validate
+// returns an error for it.
+func ParseStrict(s string) (uuid UUID, err error) {
+	if err = parse(&uuid, s); err != nil {
+		return uuid, parseError(s, err)
+	}
+	if err = Validate(uuid); err != nil {
+		return Nil, err
+	}
+	return uuid, nil
+}
+
+// ParseLenient is like Parse, but ignores the leading and trailing ASCII
+// whitespace (space, tab, newline, carriage return, vertical tab and form
+// feed), such as the trailing newline of a line read from a file. The
+// whitespace inside the UUID is not removed and is rejected. Parse and
+// UnmarshalText remain strict for the callers which need the exact form.
+func ParseLenient(s string) (uuid UUID, err error) {
+	s = trimASCIISpace(s)
+	if err = parse(&uuid, s); err != nil {
+		return uuid, parseError(s, err)
+	}
+	return uuid, nil
+}
+
+// ParseAny is more permissive than Parse: it ignores all the dashes wherever
+// they are and the optional surrounding braces, and requires exactly 32 hex
+// digits in any case to remain, for example:
+//  "6ba7b810-9dad11d1-80b400c04fd430c8",
+//  "{6ba7b810-9dad-11d1-80b4-00c0-4fd4-30c8}",
+//  "--6ba7b8109dad11d180b400c04fd430c8"
+// It is intended for cleaning the data; use Parse to accept only the standard
+// forms.
+func ParseAny(s string) (uuid UUID, err error) {
+	text := s
+	if n := len(text); n > 0 && (text[0] == '{') != (text[n-1] == '}') {
+		return uuid, parseError(s, ErrMismatchedBraces)
+	} else if n > 0 && text[0] == '{' {
+		text = text[1 : n-1]
+	}
+	var digits [32]byte
+	n := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == '-' {
+			continue
+		}
+		if n == len(digits) {
+			return uuid, parseError(s, ErrInvalidLength)
+		}
+		digits[n] = text[i]
+		n++
+	}
+	if n != len(digits) {
+		return uuid, parseError(s, ErrInvalidLength)
+	}
+	if !decodeHex(&uuid, digits[:], false) {
+		return uuid, parseError(s, ErrInvalidHex)
+	}
+	return uuid, nil
+}
+
+// trimASCIISpace returns s without the leading and trailing ASCII whitespace.
+func trimASCIISpace(s string) string {
+	isSpace := func(c byte) bool {
+		switch c {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			return true
+		}
+		return false
+	}
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	for len(s) > 0 && isSpace(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// Validate returns an error if the UUID is not a real identifier generated
+// by one of the algorithms of RFC 9562: the Nil and Max placeholder UUIDs, the
+// variant other than RFC 4122 and the version outside the range 1 to 8 are
+// rejected.
+func Validate(u UUID) error {
+	switch {
+	case u.IsNil():
+		return errors.New("uuid: nil UUID is not allowed")
+	case u.IsMax():
+		return errors.New("uuid: max UUID is not allowed")
+	}
+	return u.checkLayout()
+}
+
+// checkLayout returns an error if the variant of the UUID is not RFC 4122 or
+// the version is not in the range 1 to 8.
+func (u UUID) checkLayout() error {
+	if u.Variant() != VariantRFC4122 {
+		return fmt.Errorf("uuid: invalid UUID variant %s: %s", u.variantName(), u)
+	}
+	if u.Version() < 1 || u.Version() > 8 {
+		return fmt.Errorf("uuid: invalid UUID version %d: %s", u.Version(), u)
+	}
+	return nil
+}
+
+// NeedsNormalization reports whether the valid UUID string differs from its
+// canonical form, for example is uppercase, braced, URN or without dashes.
+// Returns false for the canonical form and an error if the string can't be
+// parsed.
+func NeedsNormalization(s string) (bool, error) {
+	uuid, err := Parse(s)
+	if err != nil {
+		return false, err
+	}
+	var canonical [36]byte
+	uuid.AppendCanonicalArray(&canonical)
+	return string(canonical[:]) != s, nil
+}
+
+// MustParse is like Parse but panics if the string can't be parsed. It
+// simplifies the initialization of global variables holding UUIDs:
+//  var myNamespace = uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+func MustParse(s string) UUID {
+	uuid, err := Parse(s)
+	if err != nil {
+		panic(`uuid: Parse(` + strconv.Quote(s) + `): ` + err.(*ParseError).Err.Error())
+	}
+	return uuid
+}
+
+// ParseCollecting parses all the inputs and returns the parallel slices of
+// the UUIDs and the errors. If an input can't be parsed, the UUID in the same
+// position is the zero value and the error is set; otherwise the error is nil.
+func ParseCollecting(inputs []string) (results []UUID, errs []error) {
+	results = make([]UUID, len(inputs))
+	errs = make([]error, len(inputs))
+	for i, s := range inputs {
+		if uuid, err := Parse(s); err != nil {
+			errs[i] = err
+		} else {
+			results[i] = uuid
+		}
+	}
+	return results, errs
+}
+
+// ParseCaseSensitive parses and returns a UUID from its string representation,
+// like Parse, but accepts only the lowercase canonical form or the lowercase
+// form without dashes:
+//  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+//  "6ba7b8109dad11d180b400c04fd430c8"
+// Any uppercase hex digit is rejected, so the parsed UUID always has exactly
+// the same string representation as the input.
+func ParseCaseSensitive(s string) (uuid UUID, err error) {
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return uuid, parseError(s, ErrInvalidFormat)
+		}
+	default:
+		return uuid, parseError(s, ErrInvalidLength)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 'A' && s[i] <= 'F' {
+			return uuid, parseError(s, ErrInvalidFormat) // uppercase hex digit
+		}
+	}
+	return Parse(s)
+}
+
+// ClassifyInvalid returns the category of the problem with the UUID string
+// without parsing it:
+//  "ok"          — the string has one of the forms accepted by Parse
+//  "too-short"   — the string is shorter than the form requires, for example
+//                  truncated or without the closing brace
+//  "too-long"    — the string is longer than the form allows
+//  "bad-hyphens" — the string has the length of the dashed form, but the
+//                  dashes are misplaced
+//  "non-hex"     — the string has the right structure but contains a
+//                  character which is not a hex digit
+func ClassifyInvalid(s string) string {
+	var truncated, prefixed bool
+	switch {
+	case len(s) >= 9 && strings.EqualFold(s[:9], "urn:uuid:"):
+		s = s[9:]
+		prefixed = true
+	case len(s) > 0 && s[0] == '{':
+		s = s[1:]
+		if len(s) > 0 && s[len(s)-1] == '}' {
+			s = s[:len(s)-1]
+		} else {
+			truncated = true
+		}
+		prefixed = true
+	}
+	switch {
+	case len(s) < 32, len(s) < 36 && strings.IndexByte(s, '-') >= 0:
+		return "too-short"
+	case prefixed && len(s) < 36:
+		// the braced and URN forms require the 36-byte dashed form
+		return "too-short"
+	case len(s) > 36, len(s) > 32 && len(s) < 36:
+		return "too-long"
+	case len(s) == 36:
+		for i := 0; i < len(s); i++ {
+			if (s[i] == '-') != (i == 8 || i == 13 || i == 18 || i == 23) {
+				return "bad-hyphens"
+			}
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHex(s[i]) && s[i] != '-' {
+			return "non-hex"
+		}
+	}
+	if truncated {
+		return "too-short"
+	}
+	return "ok"
+}
+
+// isHex returns true if c is a hex digit in any case.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// ParseHexDump parses the UUID from the hex dump of its 16 bytes, where the
+// hex digits may be separated by any ASCII whitespace:
+//  "6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 c8"
+// Returns an error if exactly 32 hex digits do not remain after removing the
+// whitespace.
+func ParseHexDump(s string) (uuid UUID, err error) {
+	digits := make([]byte, 0, 32)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+		default:
+			if len(digits) == 32 {
+				return uuid, parseError(s, ErrInvalidLength)
+			}
+			digits = append(digits, c)
+		}
+	}
+	if len(digits) != 32 {
+		return uuid, parseError(s, ErrInvalidLength)
+	}
+	if !decodeHex(&uuid, digits, false) {
+		return uuid, parseError(s, ErrInvalidHex)
+	}
+	return uuid, nil
 }
 
 // GetBSON returns a representation of the unique identifier in the form of the
-// BSON binary object with the set type UUID.
+// BSON binary object with the set type UUID. The binary data is the 16 bytes
+// in the same order as the hex digits of String, so the copies of the UUID
+// stored in BSON and as a string are equal after decoding.
 func (u UUID) GetBSON() (interface{}, error) {
 	return bson.Binary{
 		Kind: 0x04,      // тип UUID
@@ -144,13 +1142,50 @@ func (u UUID) GetBSON() (interface{}, error) {
 }
 
 // SetBSON deserializes the UUID from the internal binary representation of JSON.
+// Both the standard subtype 0x04 and the legacy subtype 0x03 written by the old
+// drivers are accepted. The legacy subtype is decoded in the byte order of the
+// old C# driver, like EqualBSON; the UUIDs written by the old Java driver with
+// its own byte order are not recognized and are decoded differently.
 func (u *UUID) SetBSON(raw bson.Raw) error {
 	var bin = new(bson.Binary)
 	if err := raw.Unmarshal(bin); err != nil {
 		return err
 	}
-	if bin.Kind != 0x04 {
-		return errors.New("bson: bad UUID binary type")
+	uuid, err := fromBSONBinary(*bin)
+	if err != nil {
+		return err
+	}
+	*u = uuid
+	return nil
+}
+
+// EqualBSON returns true if both BSON binary objects contain the same UUID.
+// The standard subtype 0x04 contains the UUID bytes as is. The legacy subtype
+// 0x03 is decoded in the byte order of the old C# driver, with the first three
+// fields swapped as in .NET Guid.ToByteArray(). Returns an error if either
+// object has another subtype or is not 16 bytes long.
+func EqualBSON(a, b bson.Binary) (bool, error) {
+	uuidA, err := fromBSONBinary(a)
+	if err != nil {
+		return false, err
+	}
+	uuidB, err := fromBSONBinary(b)
+	if err != nil {
+		return false, err
+	}
+	return uuidA.Equal(uuidB), nil
+}
+
+// fromBSONBinary returns the UUID from the BSON binary object of the standard
+// 0x04 or the legacy C# 0x03 subtype.
+func fromBSONBinary(bin bson.Binary) (uuid UUID, err error) {
+	switch bin.Kind {
+	case 0x04:
+		err = uuid.UnmarshalBinary(bin.Data)
+	case 0x03:
+		uuid, err = FromGUIDBytes(bin.Data)
+	default:
+		err = errors.New("bson: bad UUID binary type")
 	}
-	return u.UnmarshalBinary(bin.Data)
+	return uuid, err
 }
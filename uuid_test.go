@@ -2,9 +2,19 @@ package uuid
 
 import (
 	"bytes"
+	"encoding"
 	"encoding/gob"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/globalsign/mgo/bson"
 )
@@ -46,11 +56,15 @@ func TestUUID(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	newUUID = Nil
 	err = gob.NewDecoder(&buf).Decode(&newUUID)
 	if err != nil {
 		t.Error(err)
 	}
 	println("RESTORE:", newUUID.String())
+	if !uuid.Equal(newUUID) {
+		t.Error("bad gob restore")
+	}
 
 	data, err = bson.Marshal(bson.Binary{
 		Kind: 0x05,
@@ -97,3 +111,1427 @@ func TestUUIDUnmarshal(t *testing.T) {
 		t.Error("bad unmarshal")
 	}
 }
+
+func TestParseCaseSensitive(t *testing.T) {
+	for _, uuidStr := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		uuid, err := ParseCaseSensitive(uuidStr)
+		if err != nil {
+			t.Error(err)
+		}
+		if want, _ := Parse(uuidStr); !uuid.Equal(want) {
+			t.Error("bad parse", uuidStr)
+		}
+	}
+	for _, uuidStr := range []string{
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6ba7b810-9dad-11d1-80B4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430C8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b400-c04fd430c8",
+		"6ba7b810",
+	} {
+		if _, err := ParseCaseSensitive(uuidStr); err == nil {
+			t.Error("bad case sensitive parse", uuidStr)
+		}
+	}
+}
+
+func TestInt64Pair(t *testing.T) {
+	for _, test := range []struct {
+		uuid   string
+		hi, lo int64
+	}{
+		{"00000000-0000-0000-0000-000000000000", 0, 0},
+		{"00000000-0000-0001-0000-000000000001", 1, 1},
+		{"7fffffff-ffff-ffff-7fff-ffffffffffff", math.MaxInt64, math.MaxInt64},
+		{"80000000-0000-0000-8000-000000000000", math.MinInt64, math.MinInt64},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", -1, -1},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", 0x6ba7b8109dad11d1, -0x7f4bff3fb02bcf38},
+	} {
+		uuid, err := Parse(test.uuid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hi, lo := uuid.Int64Pair()
+		if hi != test.hi || lo != test.lo {
+			t.Error("bad int64 pair", test.uuid, hi, lo)
+		}
+		if !FromInt64Pair(hi, lo).Equal(uuid) {
+			t.Error("bad restore", test.uuid)
+		}
+	}
+}
+
+func TestVersionClass(t *testing.T) {
+	for version := uint(0); version < 16; version++ {
+		var uuid UUID
+		uuid[6] = byte(version << 4)
+		nameBased := version == 3 || version == 5
+		timeBased := version == 1 || version == 6 || version == 7
+		if uuid.IsNameBased() != nameBased {
+			t.Error("bad name-based", version)
+		}
+		if uuid.IsTimeBased() != timeBased {
+			t.Error("bad time-based", version)
+		}
+	}
+}
+
+func TestParseCollecting(t *testing.T) {
+	inputs := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"12345678",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430cw",
+	}
+	results, errs := ParseCollecting(inputs)
+	if len(results) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatal("bad length", len(results), len(errs))
+	}
+	for i, valid := range []bool{true, false, true, false} {
+		if valid != (errs[i] == nil) {
+			t.Error("bad error", inputs[i], errs[i])
+		}
+		if valid == (results[i] == UUID{}) {
+			t.Error("bad result", inputs[i], results[i])
+		}
+	}
+}
+
+func TestPretty(t *testing.T) {
+	uuid := New()
+	want := "UUID:    " + uuid.String() + "\nVersion: 4\nVariant: RFC4122"
+	if str := uuid.Pretty(); str != want {
+		t.Errorf("bad pretty v4:\n%s", str)
+	}
+	uuid, _ = Parse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	want = "UUID:    017f22e2-79b0-7cc3-98c4-dc0c0c07398f\nVersion: 7\n" +
+		"Variant: RFC4122\nTime:    2022-02-22T19:22:22Z"
+	if str := uuid.Pretty(); str != want {
+		t.Errorf("bad pretty v7:\n%s", str)
+	}
+}
+
+func TestParseHexDump(t *testing.T) {
+	want, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, dump := range []string{
+		"6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 c8",
+		"6ba7 b810 9dad 11d1 80b4 00c0 4fd4 30c8",
+		"  6b\ta7b810 9dad11d1\n80b400c0\r\n4fd430c8 \n",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		uuid, err := ParseHexDump(dump)
+		if err != nil {
+			t.Error(err)
+		}
+		if !uuid.Equal(want) {
+			t.Error("bad hex dump", dump)
+		}
+	}
+	for _, dump := range []string{
+		"",
+		"6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30",
+		"6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 c8 00",
+		"6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 c",
+		"6b-a7-b8-10-9d-ad-11-d1-80-b4-00-c0-4f-d4-30-c8",
+		"6b a7 b8 10 9d ad 11 d1 80 b4 00 c0 4f d4 30 cw",
+	} {
+		if _, err := ParseHexDump(dump); err == nil {
+			t.Error("bad hex dump", dump)
+		}
+	}
+}
+
+func TestGroupByVersion(t *testing.T) {
+	if groups := GroupByVersion(nil); groups == nil || len(groups) != 0 {
+		t.Error("bad empty groups", groups)
+	}
+	var uuids []UUID
+	for _, uuidStr := range []string{
+		"00000000-0000-0000-0000-000000000000",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398e",
+	} {
+		uuid, _ := Parse(uuidStr)
+		uuids = append(uuids, uuid)
+	}
+	uuids = append(uuids, New())
+	groups := GroupByVersion(uuids)
+	for version, want := range map[uint][]UUID{
+		0:  {uuids[0]},
+		1:  {uuids[1], uuids[4]},
+		4:  {uuids[6]},
+		7:  {uuids[2], uuids[5]},
+		15: {uuids[3]},
+	} {
+		group := groups[version]
+		if len(group) != len(want) {
+			t.Fatal("bad group", version, group)
+		}
+		for i := range want {
+			if !group[i].Equal(want[i]) {
+				t.Error("bad group order", version, group)
+			}
+		}
+	}
+	if len(groups) != 5 {
+		t.Error("bad groups number", len(groups))
+	}
+}
+
+func TestPath(t *testing.T) {
+	uuid, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, test := range []struct {
+		levels, width int
+		want          string
+	}{
+		{0, 0, "6ba7b8109dad11d180b400c04fd430c8"},
+		{2, 2, "6b/a7/6ba7b8109dad11d180b400c04fd430c8"},
+		{3, 1, "6/b/a/6ba7b8109dad11d180b400c04fd430c8"},
+		{1, 32, "6ba7b8109dad11d180b400c04fd430c8/6ba7b8109dad11d180b400c04fd430c8"},
+	} {
+		if path := uuid.Path(test.levels, test.width); path != test.want {
+			t.Error("bad path", test.levels, test.width, path)
+		}
+	}
+	for _, test := range [][2]int{
+		{3, 11}, {-1, 2}, {2, -1}, {3, 0},
+		{math.MaxInt, math.MaxInt}, {math.MaxInt/4 + 1, 4}, // levels*width overflows
+	} {
+		func() {
+			defer func() {
+				if msg, _ := recover().(string); !strings.HasPrefix(msg, "uuid: invalid path") {
+					t.Error("bad panic", test, msg)
+				}
+			}()
+			uuid.Path(test[0], test[1])
+		}()
+	}
+}
+
+func TestLooksLikeOurs(t *testing.T) {
+	if !New().LooksLikeOurs() {
+		t.Error("bad v4")
+	}
+	for _, uuidStr := range []string{
+		"00000000-0000-0000-0000-000000000000",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-41d1-00b4-00c04fd430c8",
+		"6ba7b810-9dad-41d1-c0b4-00c04fd430c8",
+	} {
+		uuid, _ := Parse(uuidStr)
+		if uuid.LooksLikeOurs() {
+			t.Error("bad foreign uuid", uuidStr)
+		}
+	}
+}
+
+func TestAppendCanonicalArray(t *testing.T) {
+	for _, uuid := range []UUID{New(), {}, New()} {
+		var buf [36]byte
+		uuid.AppendCanonicalArray(&buf)
+		if string(buf[:]) != uuid.String() {
+			t.Error("bad canonical array", string(buf[:]))
+		}
+	}
+}
+
+func BenchmarkAppendCanonicalArray(b *testing.B) {
+	uuid := New()
+	var buf [36]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		uuid.AppendCanonicalArray(&buf)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	uuid := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = uuid.String()
+	}
+}
+
+func BenchmarkStringSprintf(b *testing.B) {
+	uuid := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+	}
+}
+
+func TestString(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		uuid := New()
+		want := fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+		if uuid.String() != want {
+			t.Error("bad string", uuid.String(), want)
+		}
+	}
+}
+
+func TestOracleRaw(t *testing.T) {
+	uuid, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	raw := uuid.OracleRaw()
+	if raw != "6BA7B8109DAD11D180B400C04FD430C8" {
+		t.Error("bad oracle raw", raw)
+	}
+	newUUID, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !newUUID.Equal(uuid) {
+		t.Error("bad restore", newUUID)
+	}
+}
+
+func TestHex(t *testing.T) {
+	uuid, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if hex := uuid.Hex(); hex != "6ba7b8109dad11d180b400c04fd430c8" {
+		t.Error("bad hex", hex)
+	}
+	for i := 0; i < 100; i++ {
+		uuid := New()
+		hex := uuid.Hex()
+		if hex != strings.Replace(uuid.String(), "-", "", -1) {
+			t.Error("bad hex", hex)
+		}
+		if newUUID, err := Parse(hex); err != nil || newUUID != uuid {
+			t.Error("bad restore", newUUID, err)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var uuid UUID
+	for _, data := range []string{`""`, `"   "`, `"\t"`} {
+		err := json.Unmarshal([]byte(data), &uuid)
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Error("bad empty error", data, err)
+		}
+	}
+	for _, data := range []string{`123`, `{}`, `"6ba7b810"`} {
+		if json.Unmarshal([]byte(data), &uuid) == nil {
+			t.Error("bad unmarshal", data)
+		}
+	}
+	for _, data := range []string{`123`, `{}`, `[]`, `true`, `"`} {
+		err := uuid.UnmarshalJSON([]byte(data))
+		if err == nil || !strings.Contains(err.Error(), "not a string") {
+			t.Error("bad non-string error", data, err)
+		}
+	}
+	data := []byte(`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`)
+	if n := testing.AllocsPerRun(100, func() { _ = uuid.UnmarshalJSON(data) }); n != 0 {
+		t.Error("UnmarshalJSON allocates", n)
+	}
+	uuid = New()
+	if err := json.Unmarshal([]byte(`null`), &uuid); err != nil || uuid != (UUID{}) {
+		t.Error("bad null", uuid, err)
+	}
+	for _, data := range []string{
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"6BA7B810-9DAD-11D1-80B4-00C04FD430C8"`,
+		`"\u0036ba7b810-9dad-11d1-80b4-00c04fd430c8"`, // escaped, decoded with json.Unmarshal
+		`"{6ba7b810\u002d9dad-11d1-80b4-00c04fd430c8}"`,
+	} {
+		if err := json.Unmarshal([]byte(data), &uuid); err != nil {
+			t.Error(err)
+		}
+		if uuid.String() != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+			t.Error("bad unmarshal", data, uuid)
+		}
+	}
+}
+
+func TestEqualStrings(t *testing.T) {
+	for _, test := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", true},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", true},
+		{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b8109dad11d180b400c04fd430c8", true},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b811-9dad-11d1-80b4-00c04fd430c8", false},
+		{"6ba7b810", "6ba7b810", false},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "", false},
+	} {
+		if EqualStrings(test.a, test.b) != test.equal {
+			t.Error("bad equal strings", test.a, test.b)
+		}
+	}
+}
+
+func TestInfo(t *testing.T) {
+	uuid, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	info := uuid.Info()
+	if info.Canonical != uuid.String() || info.Version != 1 ||
+		info.Variant != "RFC4122" || info.Node != "00:c0:4f:d4:30:c8" {
+		t.Errorf("bad v1 info %+v", info)
+	}
+	if info.Time == nil || !info.Time.Equal(time.Date(1998, 2, 4, 22, 13, 53, 151182400, time.UTC)) {
+		t.Error("bad v1 time", info.Time)
+	}
+
+	uuid = New()
+	info = uuid.Info()
+	if info.Canonical != uuid.String() || info.Version != 4 ||
+		info.Variant != "RFC4122" || info.Time != nil || info.Node != "" {
+		t.Errorf("bad v4 info %+v", info)
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"canonical":"`+uuid.String()+`","version":4,"variant":"RFC4122"}` {
+		t.Error("bad v4 json", string(data))
+	}
+}
+
+func TestClassifyInvalid(t *testing.T) {
+	for str, want := range map[string]string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8":          "ok",
+		"6BA7B8109DAD11D180B400C04FD430C8":              "ok",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}":        "ok",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8": "ok",
+		"":                                          "too-short",
+		"6ba7b810-9dad-11d1-80b4-00c04f":            "too-short",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c":       "too-short",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8":     "too-short",
+		"6ba7b8109dad11d180b400c04fd430c80":         "too-long",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c80":     "too-long",
+		"6ba7b810-9dad-11d1-80b400-c04fd430c8":      "bad-hyphens",
+		"6ba7b8109dad-11d1-80b4-00c04fd430c8-":      "bad-hyphens",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cw":      "non-hex",
+		"6ba7b8109dad11d180b400c04fd430cg":          "non-hex",
+		"6ba7b8109dad11d180b400c04fd430c-":          "too-short",
+		"{6ba7b8109dad11d180b400c04fd430c8}":        "too-short",
+		"urn:uuid:6ba7b8109dad11d180b400c04fd430c8": "too-short",
+	} {
+		if class := ClassifyInvalid(str); class != want {
+			t.Errorf("bad class for %q: %s, want %s", str, class, want)
+		}
+		if (want == "ok") != IsValid(str) {
+			t.Errorf("ClassifyInvalid and IsValid disagree on %q", str)
+		}
+	}
+}
+
+func TestNewInRange(t *testing.T) {
+	for _, test := range [][2]string{
+		{"00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b810-9dad-11d1-80b4-00c04fd430ca"},
+		{"6ba7b810-9dad-11d1-80b4-ffffffffffff", "6ba7b810-9dad-11d1-80b5-000000000000"},
+		{"40000000-0000-4000-8000-000000000000", "40000000-0000-4000-8fff-ffffffffffff"},
+	} {
+		lo, _ := Parse(test[0])
+		hi, _ := Parse(test[1])
+		for i := 0; i < 100; i++ {
+			uuid, err := NewInRange(lo, hi)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Compare(uuid[:], lo[:]) < 0 || bytes.Compare(uuid[:], hi[:]) >= 0 {
+				t.Fatal("out of range", uuid, lo, hi)
+			}
+		}
+	}
+	lo := New()
+	if _, err := NewInRange(lo, lo); err == nil {
+		t.Error("bad empty range")
+	}
+	if _, err := NewInRange(Max, lo); err == nil {
+		t.Error("bad reversed range")
+	}
+}
+
+func TestWriteCanonical(t *testing.T) {
+	uuid := New()
+	var buf bytes.Buffer
+	n, err := uuid.WriteCanonical(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 36 || buf.String() != uuid.String() {
+		t.Error("bad write", n, buf.String())
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	uuid := New()
+	var buf bytes.Buffer
+	var writerTo io.WriterTo = uuid
+	n, err := writerTo.WriteTo(&buf)
+	if err != nil || n != 36 || buf.String() != uuid.String() {
+		t.Error("bad write to", n, err, buf.String())
+	}
+	if n := testing.AllocsPerRun(100, func() { uuid.WriteTo(io.Discard) }); n >= 1 { // the pool may be emptied by GC
+		t.Error("WriteTo allocates", n)
+	}
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	uuid := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		uuid.WriteTo(io.Discard)
+	}
+}
+
+func BenchmarkWriteCanonical(b *testing.B) {
+	uuid := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		uuid.WriteCanonical(io.Discard)
+	}
+}
+
+func BenchmarkWriteString(b *testing.B) {
+	uuid := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		io.WriteString(io.Discard, uuid.String())
+	}
+}
+
+func TestEqualBSON(t *testing.T) {
+	uuid, _ := Parse("00112233-4455-6677-8899-aabbccddeeff")
+	standard := bson.Binary{Kind: 0x04, Data: uuid.Bytes()}
+	legacy := bson.Binary{Kind: 0x03, Data: []byte{
+		0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66,
+		0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}}
+	for _, test := range []struct {
+		a, b  bson.Binary
+		equal bool
+	}{
+		{standard, legacy, true},
+		{legacy, standard, true},
+		{standard, standard, true},
+		{legacy, legacy, true},
+		{standard, bson.Binary{Kind: 0x03, Data: uuid.Bytes()}, false},
+		{standard, bson.Binary{Kind: 0x04, Data: New().Bytes()}, false},
+	} {
+		equal, err := EqualBSON(test.a, test.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if equal != test.equal {
+			t.Error("bad equal", test.a, test.b)
+		}
+	}
+	for _, bin := range []bson.Binary{
+		{Kind: 0x00, Data: uuid.Bytes()},
+		{Kind: 0x03, Data: uuid.Bytes()[1:]},
+		{Kind: 0x04, Data: uuid.Bytes()[1:]},
+	} {
+		if _, err := EqualBSON(standard, bin); err == nil {
+			t.Error("bad binary", bin)
+		}
+	}
+}
+
+func TestNewInto(t *testing.T) {
+	if err := NewInto(nil); err != nil {
+		t.Error(err)
+	}
+	uuids := make([]UUID, 100)
+	if err := NewInto(uuids); err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[UUID]bool)
+	for _, uuid := range uuids {
+		if !uuid.LooksLikeOurs() {
+			t.Error("bad version or variant", uuid)
+		}
+		if seen[uuid] {
+			t.Error("duplicate", uuid)
+		}
+		seen[uuid] = true
+	}
+}
+
+func BenchmarkNewInto(b *testing.B) {
+	uuids := make([]UUID, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := NewInto(uuids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNew1000(b *testing.B) {
+	uuids := make([]UUID, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := range uuids {
+			uuids[j] = New()
+		}
+	}
+}
+
+func TestNeedsNormalization(t *testing.T) {
+	for str, want := range map[string]bool{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8":          false,
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8":          true,
+		"6ba7b810-9dad-11d1-80b4-00C04FD430C8":          true,
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}":        true,
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8": true,
+		"6ba7b8109dad11d180b400c04fd430c8":              true,
+	} {
+		needs, err := NeedsNormalization(str)
+		if err != nil {
+			t.Error(err)
+		}
+		if needs != want {
+			t.Error("bad normalization", str)
+		}
+	}
+	if _, err := NeedsNormalization("6ba7b810"); err == nil {
+		t.Error("bad invalid string")
+	}
+}
+
+func TestNil(t *testing.T) {
+	if !Nil.IsNil() || New().IsNil() {
+		t.Error("bad nil")
+	}
+	uuid, err := Parse("00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uuid.IsNil() || !uuid.Equal(Nil) {
+		t.Error("bad parsed nil", uuid)
+	}
+	if Nil.String() != "00000000-0000-0000-0000-000000000000" {
+		t.Error("bad nil string", Nil)
+	}
+	if (UUID{15: 1}).IsNil() {
+		t.Error("bad non-nil")
+	}
+}
+
+func TestAppend(t *testing.T) {
+	uuid := New()
+	buf := uuid.Append([]byte("id="))
+	if string(buf) != "id="+uuid.String() {
+		t.Error("bad append", string(buf))
+	}
+	buf = make([]byte, 0, 72)
+	buf = uuid.Append(buf)
+	buf = New().Append(buf)
+	if len(buf) != 72 || cap(buf) != 72 || string(buf[:36]) != uuid.String() {
+		t.Error("bad append to preallocated buffer", string(buf))
+	}
+	var appender encoding.TextAppender = uuid
+	text, err := appender.AppendText(nil)
+	if err != nil || string(text) != uuid.String() {
+		t.Error("bad append text", string(text), err)
+	}
+	var binaryAppender encoding.BinaryAppender = uuid
+	data, err := binaryAppender.AppendBinary([]byte{0xff})
+	if err != nil || len(data) != 17 || data[0] != 0xff || !bytes.Equal(data[1:], uuid.Bytes()) {
+		t.Error("bad append binary", data, err)
+	}
+	if data, _ := uuid.MarshalBinary(); !bytes.Equal(data, uuid.Bytes()) {
+		t.Error("bad marshal binary", data)
+	}
+}
+
+// benchmarkUUIDs are encoded by the benchmarks to benchmarkSink.
+var (
+	benchmarkUUIDs = NewBatch(64)
+	benchmarkSink  []byte
+)
+
+func BenchmarkMarshalText(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, uuid := range benchmarkUUIDs {
+			benchmarkSink, _ = uuid.MarshalText()
+		}
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 36*len(benchmarkUUIDs))
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, uuid := range benchmarkUUIDs {
+			buf, _ = uuid.AppendText(buf)
+		}
+	}
+	benchmarkSink = buf
+}
+
+func BenchmarkMarshalBinary(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, uuid := range benchmarkUUIDs {
+			benchmarkSink, _ = uuid.MarshalBinary()
+		}
+	}
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 16*len(benchmarkUUIDs))
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, uuid := range benchmarkUUIDs {
+			buf, _ = uuid.AppendBinary(buf)
+		}
+	}
+	benchmarkSink = buf
+}
+
+func TestUnmarshalTextLength(t *testing.T) {
+	for _, test := range []struct {
+		name, text string
+	}{
+		{"empty", ""},
+		{"trailing garbage", "6ba7b810-9dad-11d1-80b4-00c04fd430c8EXTRA"},
+		{"trailing byte", "6ba7b810-9dad-11d1-80b4-00c04fd430c80"},
+		{"hex trailing byte", "6ba7b8109dad11d180b400c04fd430c80"},
+		{"hex missing byte", "6ba7b8109dad11d180b400c04fd430c"},
+		{"dashed missing byte", "6ba7b810-9dad-11d1-80b4-00c04fd430c"},
+		{"partial dashes", "6ba7b810-9dad11d180b400c04fd430c8"},
+		{"misplaced dashes", "6ba7b810-9dad-11d180-b4-00c04fd430c8"},
+		{"braced trailing garbage", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}x"},
+		{"urn trailing garbage", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8x"},
+		{"urn missing byte", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c"},
+		{"bad urn prefix", "urn:uuix:6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"bad hex", "6ba7b810-9dad-11d1-80b4-00c04fd430cx"},
+	} {
+		var uuid UUID
+		if err := uuid.UnmarshalText([]byte(test.text)); err == nil {
+			t.Error("bad unmarshal:", test.name)
+		}
+		if !uuid.IsNil() {
+			t.Error("modified on error:", test.name)
+		}
+	}
+}
+
+func TestUnmarshalTextBraces(t *testing.T) {
+	for text, valid := range map[string]bool{
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}":  true,
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8":   false,
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8}":   false,
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8)":  false,
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8}":  false,
+		"{{6ba7b810-9dad-11d1-80b4-00c04fd430c8}": false,
+		"{6ba7b8109dad11d180b400c04fd430c8}":      false,
+	} {
+		var uuid UUID
+		err := uuid.UnmarshalText([]byte(text))
+		if valid != (err == nil) {
+			t.Error("bad braces", text, err)
+		}
+	}
+	var uuid UUID
+	err := uuid.UnmarshalText([]byte("{6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	if err == nil || !strings.Contains(err.Error(), "braces") {
+		t.Error("bad braces error", err)
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	want := New()
+	uuid, err := FromBytes(want.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uuid.Equal(want) || !FromBytesOrNil(want.Bytes()).Equal(want) {
+		t.Error("bad from bytes", uuid)
+	}
+	for _, data := range [][]byte{nil, want.Bytes()[1:], append(want.Bytes(), 0)} {
+		if uuid, err := FromBytes(data); err == nil || !uuid.IsNil() {
+			t.Error("bad from bytes length", len(data))
+		}
+		if !FromBytesOrNil(data).IsNil() {
+			t.Error("bad from bytes or nil", len(data))
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"); !uuid.Equal(NamespaceDNS) {
+		t.Error("bad must parse", uuid)
+	}
+	defer func() {
+		if msg, ok := recover().(string); !ok || msg != `uuid: Parse("6ba7b810"): invalid UUID length` {
+			t.Error("bad panic", msg)
+		}
+	}()
+	MustParse("6ba7b810")
+}
+
+func TestSetRand(t *testing.T) {
+	defer SetRand(nil)
+	SetRand(bytes.NewReader(bytes.Repeat([]byte{0xff}, 32)))
+	if uuid := New(); uuid.String() != "ffffffff-ffff-4fff-bfff-ffffffffffff" {
+		t.Error("bad uuid from custom source", uuid)
+	}
+	if uuid := New(); uuid.String() != "ffffffff-ffff-4fff-bfff-ffffffffffff" {
+		t.Error("bad uuid from custom source", uuid)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("no panic on exhausted source")
+			}
+		}()
+		New()
+	}()
+	SetRand(nil)
+	if !New().LooksLikeOurs() {
+		t.Error("bad default source")
+	}
+}
+
+func TestNewRandom(t *testing.T) {
+	uuid, err := NewRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uuid.LooksLikeOurs() {
+		t.Error("bad version or variant", uuid)
+	}
+	defer SetRand(nil)
+	SetRand(bytes.NewReader(make([]byte, 8)))
+	if uuid, err := NewRandom(); err == nil || !uuid.IsNil() {
+		t.Error("bad error", uuid, err)
+	}
+}
+
+func TestVariant(t *testing.T) {
+	for b, want := range map[byte]byte{
+		0x00: VariantNCS,
+		0x7f: VariantNCS,
+		0x80: VariantRFC4122,
+		0xbf: VariantRFC4122,
+		0xc0: VariantMicrosoft,
+		0xdf: VariantMicrosoft,
+		0xe0: VariantFuture,
+		0xff: VariantFuture,
+	} {
+		var uuid UUID
+		uuid[8] = b
+		if variant := uuid.Variant(); variant != want {
+			t.Errorf("bad variant for %#x: %d", b, variant)
+		}
+	}
+	if New().Variant() != VariantRFC4122 {
+		t.Error("bad v4 variant")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for style, want := range map[Style]string{
+		StyleCanonical: "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		StyleUpper:     "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		StyleBraced:    "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		StyleURN:       "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		Style(100):     "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	} {
+		str := NamespaceDNS.Format(style)
+		if str != want {
+			t.Error("bad format", style, str)
+		}
+		if !EqualStrings(str, NamespaceDNS.String()) {
+			t.Error("bad restore", str)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a, b := MustParse("00000000-0000-0000-0000-000000000001"), MustParse("00000000-0000-0000-0000-000000000002")
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Error("bad compare")
+	}
+	uuids, err := NewV7N(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shuffled := slices.Clone(uuids)
+	mathrand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	slices.SortFunc(shuffled, UUID.Compare)
+	if !slices.Equal(shuffled, uuids) {
+		t.Error("bad sort order")
+	}
+}
+
+func TestSecureEqual(t *testing.T) {
+	a := New()
+	for _, b := range []UUID{a, New(), Nil, {15: a[15]}} {
+		if a.SecureEqual(b) != a.Equal(b) {
+			t.Error("bad secure equal", a, b)
+		}
+	}
+	b := a
+	b[15] ^= 1
+	if a.SecureEqual(b) {
+		t.Error("bad secure equal of different last byte")
+	}
+}
+
+func TestMax(t *testing.T) {
+	if !Max.IsMax() || New().IsMax() || Nil.IsMax() {
+		t.Error("bad max")
+	}
+	uuid, err := Parse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uuid.IsMax() || uuid.String() != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Error("bad parsed max", uuid)
+	}
+	if uuid, _ := Parse("FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFE"); uuid.IsMax() {
+		t.Error("bad non-max")
+	}
+}
+
+func TestGob(t *testing.T) {
+	type message struct {
+		ID    UUID
+		Refs  []UUID
+		Owner *UUID
+	}
+	owner := New()
+	msg := message{ID: New(), Refs: []UUID{New(), Nil, Max}, Owner: &owner}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(msg); err != nil {
+		t.Fatal(err)
+	}
+	var restored message
+	if err := gob.NewDecoder(&buf).Decode(&restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.ID != msg.ID || !slices.Equal(restored.Refs, msg.Refs) ||
+		restored.Owner == nil || *restored.Owner != owner {
+		t.Errorf("bad gob restore: %+v", restored)
+	}
+	var u UUID
+	if err := u.GobDecode(make([]byte, 15)); err == nil {
+		t.Error("GobDecode accepted 15 bytes")
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, s := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8", // v1
+		"886313e1-3b8a-5372-9b90-0c9aee199e5d", // v5
+		"{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}",
+		"00000000-0000-4000-8000-000000000000",
+	} {
+		if _, err := ParseStrict(s); err != nil {
+			t.Errorf("ParseStrict(%q): %v", s, err)
+		}
+	}
+	for _, s := range []string{
+		"00000000-0000-0000-0000-000000000000", // nil
+		"ffffffff-ffff-ffff-ffff-ffffffffffff", // max
+		"6ba7b810-9dad-01d1-80b4-00c04fd430c8", // version 0
+		"6ba7b810-9dad-91d1-80b4-00c04fd430c8", // version 9
+		"6ba7b810-9dad-11d1-00b4-00c04fd430c8", // NCS variant
+		"6ba7b810-9dad-11d1-c0b4-00c04fd430c8", // Microsoft variant
+		"6ba7b810-9dad-11d1-e0b4-00c04fd430c8", // future variant
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",  // malformed
+	} {
+		if uuid, err := ParseStrict(s); err == nil {
+			t.Errorf("ParseStrict(%q) accepted", s)
+		} else if !uuid.IsNil() {
+			t.Errorf("ParseStrict(%q) returned %v with error", s, uuid)
+		}
+	}
+	if err := Validate(New()); err != nil {
+		t.Error(err)
+	}
+	if err := Validate(NewV7()); err != nil {
+		t.Error(err)
+	}
+}
+
+// textUUID is a UUID which supports only the interface
+// encoding.TextUnmarshaler, so encoding/json unmarshals it from the string.
+type textUUID struct{ uuid UUID }
+
+func (u *textUUID) UnmarshalText(text []byte) error {
+	return u.uuid.UnmarshalText(text)
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	b.Run("direct", func(b *testing.B) {
+		b.ReportAllocs()
+		data := []byte(`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`)
+		var uuid UUID
+		for i := 0; i < b.N; i++ {
+			if err := uuid.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	data := []byte(`["6ba7b810-9dad-11d1-80b4-00c04fd430c8"]`)
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		uuids := make([]UUID, 1)
+		for i := 0; i < b.N; i++ {
+			if err := json.Unmarshal(data, &uuids); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("text", func(b *testing.B) {
+		b.ReportAllocs()
+		uuids := make([]textUUID, 1)
+		for i := 0; i < b.N; i++ {
+			if err := json.Unmarshal(data, &uuids); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestSetVersion(t *testing.T) {
+	for _, b := range []byte{0x00, 0xff, 0x5a} {
+		var uuid UUID
+		for i := range uuid {
+			uuid[i] = b
+		}
+		for v := byte(1); v <= 8; v++ {
+			uuid.SetVersion(v)
+			uuid.SetVariant()
+			if uuid.Version() != uint(v) || uuid.Variant() != VariantRFC4122 {
+				t.Errorf("bad version or variant %v for %d", uuid, v)
+			}
+			if uuid[6]&0x0f != b&0x0f || uuid[8]&0x3f != b&0x3f {
+				t.Errorf("other bits changed: %v", uuid)
+			}
+			if err := Validate(uuid); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	uuid := Max
+	uuid.SetVersion(4)
+	uuid.SetVariant()
+	if !uuid.LooksLikeOurs() {
+		t.Error("bad version 4", uuid)
+	}
+}
+
+func TestSetBSONLegacy(t *testing.T) {
+	uuid := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	data, err := bson.Marshal(bson.M{"id": bson.Binary{Kind: 0x03, Data: []byte{
+		0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66,
+		0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct{ ID UUID }
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.ID != uuid {
+		t.Error("bad legacy restore", doc.ID)
+	}
+	// re-encoding writes the standard subtype
+	data, err = bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct{ ID bson.Binary }
+	if err := bson.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.ID.Kind != 0x04 || !bytes.Equal(raw.ID.Data, uuid.Bytes()) {
+		t.Error("bad standard binary", raw.ID)
+	}
+	data, err = bson.Marshal(bson.M{"id": bson.Binary{Kind: 0x03, Data: uuid.Bytes()[1:]}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bson.Unmarshal(data, &doc); err == nil {
+		t.Error("bad legacy length")
+	}
+}
+
+func TestNewBatch(t *testing.T) {
+	if uuids := NewBatch(0); len(uuids) != 0 {
+		t.Error("bad empty batch", uuids)
+	}
+	uuids := NewBatch(1000)
+	seen := make(map[UUID]bool, len(uuids))
+	for _, uuid := range uuids {
+		if !uuid.LooksLikeOurs() {
+			t.Error("bad version or variant", uuid)
+		}
+		if seen[uuid] {
+			t.Error("duplicate", uuid)
+		}
+		seen[uuid] = true
+	}
+}
+
+func TestMust(t *testing.T) {
+	uuid := New()
+	if Must(uuid, nil) != uuid {
+		t.Error("bad Must")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Must did not panic")
+		}
+	}()
+	Must(Parse("bad"))
+}
+
+func BenchmarkNewBatch1000(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewBatch(1000)
+	}
+}
+
+func TestURN(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if urn := uuid.URN(); urn != "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Error("bad URN", urn)
+	}
+	for _, s := range []string{
+		uuid.URN(),
+		"URN:UUID:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"Urn:Uuid:6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+	} {
+		if parsed, err := Parse(s); err != nil || parsed != uuid {
+			t.Errorf("Parse(%q) = %v, %v", s, parsed, err)
+		}
+	}
+	for _, s := range []string{
+		"urn:uuid:6ba7b810-9dad-11d1-80b4",          // 32 chars starting as URN
+		"urn:uuid:6ba7b8109dad11d180b400c04fd430c8", // URN without dashes
+		"urn:oid:6ba7b810-9dad-11d1-80b4-00c04fd430c8x",
+		"urn:",
+		"u",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) accepted", s)
+		}
+	}
+	for _, s := range []string{"", "urn:uuid", "URN:UUI"} {
+		if hasURNPrefix(s) {
+			t.Errorf("hasURNPrefix(%q) = true", s)
+		}
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, s := range []string{
+		" 6ba7b810-9dad-11d1-80b4-00c04fd430c8\n",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8\r\n",
+		"\t{6ba7b810-9dad-11d1-80b4-00c04fd430c8}  ",
+		"\v\furn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		if parsed, err := ParseLenient(s); err != nil || parsed != uuid {
+			t.Errorf("ParseLenient(%q) = %v, %v", s, parsed, err)
+		}
+		if strings.TrimSpace(s) != s {
+			if _, err := Parse(s); err == nil {
+				t.Errorf("Parse(%q) accepted", s)
+			}
+		}
+	}
+	for _, s := range []string{
+		"",
+		" \n ",
+		"6ba7b810-9dad-11d1-80b4 00c04fd430c8",
+		" 6ba7b810 9dad 11d1 80b4 00c04fd430c8 ",
+		"\u00a06ba7b810-9dad-11d1-80b4-00c04fd430c8", // not ASCII whitespace
+	} {
+		if _, err := ParseLenient(s); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseLenient(%q): %v", s, err)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	restoreV7(t)
+	uuid := New()
+	if uuid.Hash() != uuid.Hash() {
+		t.Error("unstable hash")
+	}
+	if Nil.Hash() == Max.Hash() {
+		t.Error("bad hash", Nil.Hash())
+	}
+	const buckets, n = 64, 64000
+	for _, next := range []func(i int) UUID{
+		func(i int) (uuid UUID) { // counter in the last bytes
+			uuid[14], uuid[15] = byte(i>>8), byte(i)
+			return uuid
+		},
+		func(i int) (uuid UUID) { // counter in the first bytes
+			uuid[0], uuid[1] = byte(i>>8), byte(i)
+			return uuid
+		},
+		func(int) UUID { return NewV7() },
+	} {
+		var counts [buckets]int
+		for i := 0; i < n; i++ {
+			counts[next(i).Hash()%buckets]++
+		}
+		for bucket, count := range counts {
+			if count < n/buckets/2 || count > n/buckets*2 {
+				t.Errorf("skewed bucket %d: %d of %d", bucket, count, n)
+			}
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	uuid := New()
+	clone := uuid.Clone()
+	clone[0] ^= 0xff
+	if clone == uuid {
+		t.Error("clone shares the bytes")
+	}
+	if uuid.Clone() != uuid {
+		t.Error("bad clone")
+	}
+}
+
+// TestPointerReceivers guards the value semantics of UUID: only the methods
+// decoding or modifying the UUID in place may have pointer receivers.
+func TestPointerReceivers(t *testing.T) {
+	mutators := map[string]bool{
+		"GobDecode": true, "Scan": true, "SetBSON": true, "SetVariant": true,
+		"SetVersion": true, "Unmarshal": true, "UnmarshalBinary": true,
+		"UnmarshalBinaryStrict": true, "UnmarshalCBOR": true,
+		"UnmarshalJSON": true, "UnmarshalText": true, "UnmarshalXMLAttr": true,
+		"UnmarshalYAML": true,
+	}
+	values := reflect.TypeOf(UUID{})
+	pointers := reflect.TypeOf(&UUID{})
+	for i := 0; i < pointers.NumMethod(); i++ {
+		name := pointers.Method(i).Name
+		if _, ok := values.MethodByName(name); !ok && !mutators[name] {
+			t.Errorf("%s has a pointer receiver, but does not modify the UUID", name)
+		}
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, s := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad11d1-80b400c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"6-b-a-7-b-8-1-0-9-d-a-d-1-1-d-1-8-0-b-4-0-0-c-0-4-f-d-4-3-0-c-8",
+		"--6BA7B8109DAD11D180B400C04FD430C8--",
+		"{6ba7b810-9dad-11d1-80b4-00c0-4fd4-30c8}",
+	} {
+		if parsed, err := ParseAny(s); err != nil || parsed != uuid {
+			t.Errorf("ParseAny(%q) = %v, %v", s, parsed, err)
+		}
+	}
+	for _, test := range []struct {
+		s      string
+		reason error
+	}{
+		{"", ErrInvalidLength},
+		{"----", ErrInvalidLength},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c", ErrInvalidLength},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c80", ErrInvalidLength},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8-00", ErrInvalidLength},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430cg", ErrInvalidHex},
+		{"6ba7b810 9dad 11d1 80b4 00c04fd430c8", ErrInvalidLength},
+		{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrMismatchedBraces},
+		{"{{6ba7b810-9dad-11d1-80b4-00c04fd430c8}}", ErrInvalidLength},
+		{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrInvalidLength},
+	} {
+		if _, err := ParseAny(test.s); !errors.Is(err, test.reason) {
+			t.Errorf("ParseAny(%q): %v", test.s, err)
+		}
+	}
+}
+
+func TestCrossFormatEqual(t *testing.T) {
+	uuid := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	type doc struct{ ID UUID }
+	standard, err := bson.Marshal(doc{uuid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := bson.Marshal(bson.M{"id": bson.Binary{Kind: 0x03, Data: uuid.GUIDBytes()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var copies []UUID
+	for _, data := range [][]byte{standard, legacy} {
+		var d doc
+		if err := bson.Unmarshal(data, &d); err != nil {
+			t.Fatal(err)
+		}
+		copies = append(copies, d.ID)
+	}
+	for _, s := range []string{
+		uuid.String(),
+		uuid.Format(StyleUpper),
+		uuid.URN(),
+		uuid.Hex(),
+	} {
+		var d doc
+		if err := json.Unmarshal([]byte(`{"ID":"`+s+`"}`), &d); err != nil {
+			t.Fatal(err)
+		}
+		copies = append(copies, d.ID)
+	}
+	for i, u := range copies {
+		if !u.Equal(uuid) || u.String() != "00112233-4455-6677-8899-aabbccddeeff" {
+			t.Errorf("copy %d differs: %v", i, u)
+		}
+	}
+	// the standard BSON binary holds the bytes in the order of the text form
+	var raw struct{ ID bson.Binary }
+	if err := bson.Unmarshal(standard, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if hex := fmt.Sprintf("%x", raw.ID.Data); hex != uuid.Hex() {
+		t.Error("bad BSON byte order", hex)
+	}
+}
+
+func TestShort(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if short := uuid.Short(); short != "6ba7b810" {
+		t.Error("bad short", short)
+	}
+	for n := 1; n <= 32; n++ {
+		if short := uuid.ShortN(n); short != uuid.Hex()[:n] {
+			t.Error("bad short", n, short)
+		}
+	}
+	for _, n := range []int{-1, 0, 33} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("ShortN did not panic", n)
+				}
+			}()
+			uuid.ShortN(n)
+		}()
+	}
+}
+
+func TestUnmarshalBinaryStrict(t *testing.T) {
+	restoreV1(t)
+	for _, uuid := range []UUID{New(), NewV7(), NewV1(), FromString("strict")} {
+		var newUUID UUID
+		if err := newUUID.UnmarshalBinaryStrict(uuid.Bytes()); err != nil || newUUID != uuid {
+			t.Error("bad strict unmarshal", uuid, err)
+		}
+	}
+	valid := MustParse("6ba7b810-9dad-41d1-80b4-00c04fd430c8")
+	for _, test := range []struct {
+		name  string
+		patch func(u *UUID)
+		text  string
+	}{
+		{"version 0", func(u *UUID) { u[6] &= 0x0f }, "version 0"},
+		{"version 9", func(u *UUID) { u[6] = 0x91 }, "version 9"},
+		{"version 15", func(u *UUID) { u[6] |= 0xf0 }, "version 15"},
+		{"NCS variant", func(u *UUID) { u[8] &= 0x7f }, "variant NCS"},
+		{"Microsoft variant", func(u *UUID) { u[8] = 0xc0 }, "variant Microsoft"},
+		{"future variant", func(u *UUID) { u[8] = 0xe0 }, "variant Future"},
+		{"nil", func(u *UUID) { *u = Nil }, "variant NCS"},
+		{"max", func(u *UUID) { *u = Max }, "variant Future"},
+	} {
+		uuid := valid
+		test.patch(&uuid)
+		newUUID := valid
+		err := newUUID.UnmarshalBinaryStrict(uuid.Bytes())
+		if err == nil || !strings.Contains(err.Error(), test.text) {
+			t.Errorf("%s: bad error %v", test.name, err)
+		}
+		if newUUID != valid {
+			t.Errorf("%s: UUID modified on error", test.name)
+		}
+		// the lenient form accepts any bytes
+		if err := newUUID.UnmarshalBinary(uuid.Bytes()); err != nil || newUUID != uuid {
+			t.Errorf("%s: bad lenient unmarshal %v", test.name, err)
+		}
+	}
+	var newUUID UUID
+	if err := newUUID.UnmarshalBinaryStrict(valid.Bytes()[1:]); !errors.Is(err, ErrInvalidBinaryLength) {
+		t.Error("bad length error", err)
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	uuid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, line := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8\n",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8\r\n",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}\r",
+	} {
+		parsed, skip, err := ParseLine([]byte(line))
+		if err != nil || skip || parsed != uuid {
+			t.Errorf("ParseLine(%q) = %v, %v, %v", line, parsed, skip, err)
+		}
+	}
+	for _, line := range []string{"", "\n", "\r\n", "\r\n\r\n"} {
+		if _, skip, err := ParseLine([]byte(line)); err != nil || !skip {
+			t.Errorf("ParseLine(%q) = %v, %v", line, skip, err)
+		}
+	}
+	for _, line := range []string{
+		" \n",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8 \n",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c\n",
+		"\n6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	} {
+		if _, skip, err := ParseLine([]byte(line)); !errors.Is(err, ErrInvalidUUID) || skip {
+			t.Errorf("ParseLine(%q) = %v, %v", line, skip, err)
+		}
+	}
+	line := []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8\r\n")
+	if n := testing.AllocsPerRun(100, func() { ParseLine(line) }); n != 0 {
+		t.Error("ParseLine allocates", n)
+	}
+}
+
+func TestBeforeAfter(t *testing.T) {
+	a := MustParse("6ba7b810-9dad-41d1-80b4-00c04fd430c8")
+	b := MustParse("6ba7b811-0000-4000-8000-000000000000")
+	if !a.Before(b) || a.After(b) || !b.After(a) || b.Before(a) {
+		t.Error("bad lexicographic order", a, b)
+	}
+	if a.Before(a) || a.After(a) {
+		t.Error("UUID is ordered relative to itself")
+	}
+	first := NewV7()
+	time.Sleep(2 * time.Millisecond)
+	second := NewV7()
+	if !first.Before(second) || !second.After(first) {
+		t.Error("bad time order", first, second)
+	}
+	ts1, _ := first.Time()
+	ts2, _ := second.Time()
+	if !ts1.Before(ts2) {
+		t.Error("bad timestamps", ts1, ts2)
+	}
+}
@@ -0,0 +1,139 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// v1State holds the state of the version 1 UUID generation: the node ID, the
+// clock sequence and the last used timestamp.
+type v1State struct {
+	mu       sync.Mutex
+	node     [6]byte
+	hasNode  bool   // node ID is initialized
+	clockSeq uint16 // 14-bit clock sequence
+	hasSeq   bool   // clock sequence is initialized
+	last     uint64 // last used timestamp in 100-nanosecond intervals
+	clock    uint64 // last read clock time in 100-nanosecond intervals
+}
+
+var v1 v1State
+
+// SetNodeID sets the 6-byte node ID used by NewV1 instead of the hardware
+// address. Returns an error if id is not 6 bytes long.
+func SetNodeID(id []byte) error {
+	if len(id) != 6 {
+		return fmt.Errorf("uuid: node ID must be exactly 6 bytes long, got %d bytes", len(id))
+	}
+	v1.mu.Lock()
+	copy(v1.node[:], id)
+	v1.hasNode = true
+	v1.mu.Unlock()
+	return nil
+}
+
+// initNode initializes the node ID with the hardware address of the first
+// network interface which has one, or with a random multicast address.
+// Must be called with the mutex held.
+func (s *v1State) initNode() error {
+	if s.hasNode {
+		return nil
+	}
+	if interfaces, err := net.Interfaces(); err == nil {
+		for _, ifi := range interfaces {
+			if len(ifi.HardwareAddr) == 6 {
+				copy(s.node[:], ifi.HardwareAddr)
+				s.hasNode = true
+				return nil
+			}
+		}
+	}
+	if _, err := io.ReadFull(randReader, s.node[:]); err != nil {
+		return err
+	}
+	s.node[0] |= 0x01 // set multicast bit
+	s.hasNode = true
+	return nil
+}
+
+// NewV1 returns a new version 1 UUID: the 60-bit count of 100-nanosecond
+// intervals since the beginning of the Gregorian calendar, the 14-bit clock
+// sequence and the 48-bit node ID.
+//
+// The node ID is the hardware address of a network interface, or a random
+// multicast address if there is none, unless it is set by SetNodeID. The
+// clock sequence is initialized randomly and is incremented when the clock
+// goes backwards. When the clock does not advance since the previous call,
+// the timestamp of the previous UUID is incremented instead, so the UUIDs
+// generated by the process never repeat even with a coarse clock.
+func NewV1() (uuid UUID) {
+	now := uint64(time.Now().UnixNano()/100) + gregorianOffset
+	v1.mu.Lock()
+	defer v1.mu.Unlock()
+	if err := v1.initNode(); err != nil {
+		panic(err)
+	}
+	if !v1.hasSeq {
+		var seq [2]byte
+		if _, err := io.ReadFull(randReader, seq[:]); err != nil {
+			panic(err)
+		}
+		v1.clockSeq = binary.BigEndian.Uint16(seq[:]) & 0x3fff
+		v1.hasSeq = true
+	}
+	ts, clockSeq := v1.next(now)
+	binary.BigEndian.PutUint32(uuid[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(uuid[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(uuid[6:8], uint16(ts>>48)&0x0fff|0x1000) // set version 1
+	binary.BigEndian.PutUint16(uuid[8:10], clockSeq|0x8000)             // set variant 0b10
+	copy(uuid[10:], v1.node[:])
+	return
+}
+
+// next returns the timestamp and clock sequence for the clock time now. When
+// the clock goes backwards since the previous call, the clock sequence is
+// incremented and the time is used as is. Otherwise the time is used if it
+// is greater than the last used timestamp, and the last used timestamp is
+// incremented if it is not, so the pairs never repeat. Must be called with
+// the mutex held.
+func (s *v1State) next(now uint64) (uint64, uint16) {
+	switch {
+	case now < s.clock:
+		s.clockSeq = (s.clockSeq + 1) & 0x3fff
+		s.last = now
+	case now > s.last:
+		s.last = now
+	default:
+		s.last++
+	}
+	s.clock = now
+	return s.last, s.clockSeq
+}
+
+// ClockSequence returns the 14-bit clock sequence of the version 1 or 6 UUID,
+// like clockSequence of java.util.UUID. Returns -1 for other versions, which
+// have no clock sequence.
+func (u UUID) ClockSequence() int {
+	switch u.Version() {
+	case 1, 6:
+		return int(binary.BigEndian.Uint16(u[8:10]) & 0x3fff)
+	default:
+		return -1
+	}
+}
+
+// Node returns a copy of the 6-byte node ID of the version 1 or 6 UUID, which
+// is the value of node of java.util.UUID in the big-endian order. Returns nil
+// for other versions, which have no node ID.
+func (u UUID) Node() []byte {
+	switch u.Version() {
+	case 1, 6:
+		return append([]byte(nil), u[10:]...)
+	default:
+		return nil
+	}
+}
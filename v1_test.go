@@ -0,0 +1,141 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// restoreV1 saves the state of the version 1 UUID generation and restores it
+// when the test finishes, so the node ID and the timestamp set by the test do
+// not leak into the other tests.
+func restoreV1(t *testing.T) {
+	v1.mu.Lock()
+	node, hasNode := v1.node, v1.hasNode
+	clockSeq, hasSeq, last, clock := v1.clockSeq, v1.hasSeq, v1.last, v1.clock
+	v1.mu.Unlock()
+	t.Cleanup(func() {
+		v1.mu.Lock()
+		v1.node, v1.hasNode = node, hasNode
+		v1.clockSeq, v1.hasSeq, v1.last, v1.clock = clockSeq, hasSeq, last, clock
+		v1.mu.Unlock()
+	})
+}
+
+func TestNewV1(t *testing.T) {
+	restoreV1(t)
+	start := time.Now().Add(-time.Microsecond)
+	seen := make(map[UUID]bool)
+	for i := 0; i < 10000; i++ {
+		uuid := NewV1()
+		if uuid.Version() != 1 {
+			t.Fatal("bad version", uuid.Version())
+		}
+		if uuid[8]&0xc0 != 0x80 {
+			t.Fatal("bad variant", uuid)
+		}
+		if seen[uuid] {
+			t.Fatal("duplicate", uuid)
+		}
+		seen[uuid] = true
+	}
+	uuid := NewV1()
+	if ts, ok := uuid.Time(); !ok || ts.Before(start) || ts.After(time.Now()) {
+		t.Error("bad time", ts)
+	}
+
+	node := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	if err := SetNodeID(node); err != nil {
+		t.Fatal(err)
+	}
+	if uuid := NewV1(); !bytes.Equal(uuid[10:], node) {
+		t.Error("bad node", uuid)
+	}
+	if err := SetNodeID(node[1:]); err == nil {
+		t.Error("bad node length")
+	}
+}
+
+func TestV1ClockSequence(t *testing.T) {
+	restoreV1(t)
+	uuid := NewV1()
+	seq := uint16(uuid[8]&0x3f)<<8 | uint16(uuid[9])
+	v1.mu.Lock()
+	v1.clock = 1<<60 - 1 // the clock goes backwards
+	v1.mu.Unlock()
+	uuid = NewV1()
+	if newSeq := uint16(uuid[8]&0x3f)<<8 | uint16(uuid[9]); newSeq != (seq+1)&0x3fff {
+		t.Error("clock sequence not bumped", seq, newSeq)
+	}
+}
+
+func TestV1StateNext(t *testing.T) {
+	s := v1State{clockSeq: 0x3ffe}
+	for _, test := range []struct {
+		now, ts uint64
+		seq     uint16
+	}{
+		{100, 100, 0x3ffe},
+		{100, 101, 0x3ffe}, // the clock does not advance
+		{100, 102, 0x3ffe},
+		{101, 103, 0x3ffe}, // the clock advances, but not past the last timestamp
+		{110, 110, 0x3ffe},
+		{105, 105, 0x3fff}, // the clock goes backwards
+		{104, 104, 0},
+	} {
+		if ts, seq := s.next(test.now); ts != test.ts || seq != test.seq {
+			t.Error("bad next", test.now, ts, seq)
+		}
+	}
+
+	// more UUIDs than the clock sequence can hold within one clock tick
+	s = v1State{}
+	seen := make(map[[2]uint64]bool)
+	for i := 0; i < 0x4000+100; i++ {
+		ts, seq := s.next(1000)
+		key := [2]uint64{ts, uint64(seq)}
+		if seen[key] {
+			t.Fatal("duplicate", i, ts, seq)
+		}
+		seen[key] = true
+	}
+}
+
+func TestClockSequenceNode(t *testing.T) {
+	restoreV1(t)
+	for _, test := range []struct {
+		uuid string
+		seq  int
+		node []byte
+	}{
+		// NamespaceDNS, the fields are verified with java.util.UUID
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", 180, []byte{0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}},
+		{"1ef21d2f-1207-6660-8c4f-419efbd44d48", 3151, []byte{0x41, 0x9e, 0xfb, 0xd4, 0x4d, 0x48}},
+		{"6ba7b810-9dad-41d1-80b4-00c04fd430c8", -1, nil},
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", -1, nil},
+		{"00000000-0000-0000-0000-000000000000", -1, nil},
+	} {
+		uuid := mustParse(t, test.uuid)
+		if seq := uuid.ClockSequence(); seq != test.seq {
+			t.Errorf("%v: bad clock sequence %d", uuid, seq)
+		}
+		if node := uuid.Node(); !bytes.Equal(node, test.node) || (node == nil) != (test.node == nil) {
+			t.Errorf("%v: bad node % x", uuid, node)
+		}
+	}
+	node := []byte{0x02, 0x03, 0x04, 0x05, 0x06, 0x07}
+	if err := SetNodeID(node); err != nil {
+		t.Fatal(err)
+	}
+	uuid := NewV1()
+	if !bytes.Equal(uuid.Node(), node) {
+		t.Error("bad generated node", uuid.Node())
+	}
+	uuid.Node()[0] = 0xff
+	if uuid[10] != 0x02 {
+		t.Error("Node shares the bytes of the UUID")
+	}
+	if seq := uuid.ClockSequence(); seq < 0 || seq > 0x3fff {
+		t.Error("bad generated clock sequence", seq)
+	}
+}
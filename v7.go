@@ -0,0 +1,174 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// v7State holds the last used timestamp and counter of the version 7 UUID
+// generation. It guarantees that the UUIDs generated by the process are
+// strictly increasing.
+type v7State struct {
+	mu  sync.Mutex
+	ms  int64  // last used Unix time in milliseconds
+	seq uint16 // last used 12-bit counter
+}
+
+var v7 v7State
+
+// next returns the next timestamp and counter pair. The counter is reset to
+// zero every new millisecond and incremented for each UUID generated within
+// the same millisecond. When the counter overflows, or when the clock goes
+// backwards, the last used timestamp is incremented instead, so the result is
+// always strictly greater than the previous one. Must be called with the
+// mutex held.
+func (s *v7State) next(now int64) (int64, uint16) {
+	switch {
+	case now > s.ms:
+		s.ms, s.seq = now, 0
+	case s.seq < 0x0fff:
+		s.seq++
+	default:
+		s.ms, s.seq = s.ms+1, 0
+	}
+	return s.ms, s.seq
+}
+
+// setV7 fills the UUID with the version 7 layout: 48-bit timestamp in
+// milliseconds, version, 12-bit counter in rand_a, variant and 62 bits of
+// rand_b taken from the 8 bytes of random.
+func (u *UUID) setV7(ms int64, seq uint16, random []byte) {
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = 0x70 | byte(seq>>8)&0x0f // set version 7 and high counter bits
+	u[7] = byte(seq)
+	copy(u[8:], random[:8])
+	u[8] = (u[8] & 0x3f) | 0x80 // set high order byte 0b10{8,9,a,b}
+}
+
+// NewV7 returns a new version 7 UUID defined in RFC 9562: the 48-bit Unix
+// time in milliseconds, the version, the 12-bit counter, the variant and 62
+// random bits. The UUIDs sort in the order of generation, so
+// they are suitable as the database keys.
+//
+// The counter makes the UUIDs generated by the process strictly increasing:
+// it is reset to zero every new millisecond and incremented for each UUID
+// generated within the same millisecond. When 4096 UUIDs are generated within
+// one millisecond, or when the clock goes backwards, the timestamp of the last
+// UUID is incremented instead of using the current time.
+func NewV7() (uuid UUID) {
+	var random [8]byte
+	if _, err := io.ReadFull(randReader, random[:]); err != nil {
+		panic(err)
+	}
+	now := time.Now().UnixMilli()
+	v7.mu.Lock()
+	ms, seq := v7.next(now)
+	v7.mu.Unlock()
+	uuid.setV7(ms, seq, random[:])
+	return
+}
+
+// MonotonicGenerator generates version 7 UUIDs which are strictly increasing
+// in the order of the calls of New, even when it is called concurrently from
+// many goroutines, like NewV7. Unlike NewV7, each generator holds its own
+// last used timestamp and 12-bit counter, so the UUIDs of different
+// generators, and of NewV7, are not ordered relative to each other. The zero
+// value is ready to use.
+type MonotonicGenerator struct {
+	state v7State
+}
+
+// NewMonotonicGenerator returns a new generator of monotonic version 7 UUIDs.
+func NewMonotonicGenerator() *MonotonicGenerator {
+	return new(MonotonicGenerator)
+}
+
+// New returns the next version 7 UUID of the generator, greater than all the
+// UUIDs returned by the generator before. The counter is incremented within
+// the same millisecond and rolls into the next millisecond on overflow, as in
+// NewV7. It panics if the random source fails.
+func (g *MonotonicGenerator) New() (uuid UUID) {
+	var random [8]byte
+	if _, err := io.ReadFull(randReader, random[:]); err != nil {
+		panic(err)
+	}
+	g.state.mu.Lock()
+	defer g.state.mu.Unlock()
+	// the time is read under the lock, so the order of the UUIDs matches the
+	// order of the calls
+	ms, seq := g.state.next(time.Now().UnixMilli())
+	uuid.setV7(ms, seq, random[:])
+	return
+}
+
+// NewV7N returns n version 7 UUIDs which are strictly increasing within the
+// returned slice and relative to all other version 7 UUIDs generated by the
+// process. The time is read once and the random part of all UUIDs is read
+// in one call.
+func NewV7N(n int) ([]UUID, error) {
+	if n < 0 {
+		return nil, errors.New("uuid: negative number of UUIDs")
+	}
+	uuids := make([]UUID, n)
+	if n == 0 {
+		return uuids, nil
+	}
+	random := make([]byte, 8*n)
+	if _, err := io.ReadFull(randReader, random); err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	v7.mu.Lock()
+	for i := range uuids {
+		ms, seq := v7.next(now)
+		uuids[i].setV7(ms, seq, random[i*8:])
+	}
+	v7.mu.Unlock()
+	return uuids, nil
+}
+
+// NewV7Shard returns a version 7 UUID containing the shard identifier and the
+// per-shard counter instead of the most of the random bits:
+//  bits 0-47    Unix time in milliseconds
+//  bits 48-51   version 7
+//  bits 52-63   high 12 bits of the shard
+//  bits 64-65   variant 0b10
+//  bits 66-69   low 4 bits of the shard
+//  bits 70-117  low 48 bits of the counter
+//  bits 118-127 random
+// Two different shard and counter pairs never collide within the same
+// millisecond, as long as the counter does not wrap after 2^48 values, so no
+// state has to be shared between shards.
+func NewV7Shard(shard uint16, counter uint64) (uuid UUID) {
+	var random [2]byte
+	if _, err := io.ReadFull(randReader, random[:]); err != nil {
+		panic(err)
+	}
+	randB := 1<<63 | // set variant 0b10
+		uint64(shard&0x0f)<<58 |
+		(counter&(1<<48-1))<<10 |
+		uint64(binary.BigEndian.Uint16(random[:]))&0x03ff
+	binary.BigEndian.PutUint64(uuid[8:], randB)
+	seq := shard >> 4
+	uuid.setV7(time.Now().UnixMilli(), seq, uuid[8:])
+	return
+}
+
+// NewV7From returns the version 7 UUID with the timestamp t in milliseconds
+// in the first 48 bits and the provided random bytes in the remaining 10
+// bytes. The version and variant overwrite 6 of the provided bits: the high 4
+// bits of random[0] and the high 2 bits of random[2]. The result is fully
+// deterministic: it does not read or update the counter state shared by
+// NewV7 and NewV7N.
+func NewV7From(t time.Time, random [10]byte) (uuid UUID) {
+	uuid.setV7(t.UnixMilli(), binary.BigEndian.Uint16(random[:2]), random[2:])
+	return
+}
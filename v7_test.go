@@ -0,0 +1,191 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// restoreV7 saves the state of the version 7 UUID generation and restores it
+// when the test finishes, so the timestamp moved ahead of the clock by the
+// test does not leak into the other tests.
+func restoreV7(t *testing.T) {
+	v7.mu.Lock()
+	ms, seq := v7.ms, v7.seq
+	v7.mu.Unlock()
+	t.Cleanup(func() {
+		v7.mu.Lock()
+		v7.ms, v7.seq = ms, seq
+		v7.mu.Unlock()
+	})
+}
+
+func TestNewV7N(t *testing.T) {
+	restoreV7(t)
+	start := time.Now().Truncate(time.Millisecond)
+	uuids, err := NewV7N(10000) // more than 4096 counter values per millisecond
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uuids) != 10000 {
+		t.Fatal("bad length", len(uuids))
+	}
+	for i, uuid := range uuids {
+		if uuid.Version() != 7 {
+			t.Fatal("bad version", uuid.Version())
+		}
+		if uuid[8]&0xc0 != 0x80 {
+			t.Fatal("bad variant", uuid)
+		}
+		if ts, ok := uuid.Time(); !ok || ts.Before(start) {
+			t.Fatal("bad time", ts)
+		}
+		if i > 0 && bytes.Compare(uuids[i-1][:], uuid[:]) >= 0 {
+			t.Fatal("not strictly increasing", uuids[i-1], uuid)
+		}
+	}
+	next, err := NewV7N(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(uuids[len(uuids)-1][:], next[0][:]) >= 0 {
+		t.Error("not strictly increasing between batches")
+	}
+
+	if uuids, err := NewV7N(0); err != nil || len(uuids) != 0 {
+		t.Error("bad empty batch", err)
+	}
+	if _, err := NewV7N(-1); err == nil {
+		t.Error("bad negative batch")
+	}
+}
+
+func TestNewV7Shard(t *testing.T) {
+	seen := make(map[[2]uint64]bool)
+	for _, shard := range []uint16{0, 1, 0x0f, 0x10, 0xfff0, 0xffff} {
+		for _, counter := range []uint64{0, 1, 2, 1<<48 - 1} {
+			uuid := NewV7Shard(shard, counter)
+			if uuid.Version() != 7 || uuid[8]&0xc0 != 0x80 {
+				t.Fatal("bad version or variant", uuid)
+			}
+			randA := uint64(binary.BigEndian.Uint16(uuid[6:8]) & 0x0fff)
+			randB := binary.BigEndian.Uint64(uuid[8:]) &^ (3 << 62) >> 10
+			if uint16(randA<<4|randB>>48) != shard || randB&(1<<48-1) != counter {
+				t.Error("bad shard or counter", shard, counter, uuid)
+			}
+			key := [2]uint64{randA, randB}
+			if seen[key] {
+				t.Error("collision", shard, counter)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestNewV7From(t *testing.T) {
+	ts := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	random := [10]byte{0xfc, 0xc3, 0xd8, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f}
+	uuid := NewV7From(ts, random)
+	if uuid.String() != "017f22e2-79b0-7cc3-98c4-dc0c0c07398f" {
+		t.Error("bad v7", uuid)
+	}
+	if !NewV7From(ts, random).Equal(uuid) {
+		t.Error("not deterministic")
+	}
+	if newTS, ok := uuid.Time(); !ok || !newTS.Equal(ts) {
+		t.Error("bad time", newTS)
+	}
+	uuid = NewV7From(ts, [10]byte{})
+	if uuid.String() != "017f22e2-79b0-7000-8000-000000000000" {
+		t.Error("bad v7", uuid)
+	}
+}
+
+func TestNewV7(t *testing.T) {
+	restoreV7(t)
+	start := time.Now().Truncate(time.Millisecond)
+	prev := NewV7()
+	for i := 0; i < 10000; i++ {
+		uuid := NewV7()
+		if uuid.Version() != 7 {
+			t.Fatal("bad version", uuid.Version())
+		}
+		if uuid[8]&0xc0 != 0x80 {
+			t.Fatal("bad variant", uuid)
+		}
+		if bytes.Compare(prev[:], uuid[:]) >= 0 {
+			t.Fatal("not strictly increasing", prev, uuid)
+		}
+		prev = uuid
+	}
+	if ts, ok := prev.Time(); !ok || ts.Before(start) {
+		t.Error("bad time", ts)
+	}
+}
+
+func TestV7StateNext(t *testing.T) {
+	var s v7State
+	for _, test := range []struct {
+		now, ms int64
+		seq     uint16
+	}{
+		{100, 100, 0},
+		{100, 100, 1},
+		{101, 101, 0},
+		{99, 101, 1}, // clock goes backwards
+		{102, 102, 0},
+	} {
+		if ms, seq := s.next(test.now); ms != test.ms || seq != test.seq {
+			t.Error("bad next", test.now, ms, seq)
+		}
+	}
+	s.seq = 0x0fff
+	if ms, seq := s.next(102); ms != 103 || seq != 0 {
+		t.Error("bad overflow", ms, seq)
+	}
+}
+
+func TestMonotonicGenerator(t *testing.T) {
+	g := NewMonotonicGenerator()
+	const goroutines, n = 16, 2000
+	results := make([][]UUID, goroutines)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			uuids := make([]UUID, n)
+			for j := range uuids {
+				uuids[j] = g.New()
+			}
+			results[i] = uuids
+		}(i)
+	}
+	wg.Wait()
+	var all []UUID
+	for _, uuids := range results {
+		for j, uuid := range uuids {
+			if uuid.Version() != 7 || uuid.Variant() != VariantRFC4122 {
+				t.Fatal("bad version or variant", uuid)
+			}
+			// each goroutine observes strictly increasing UUIDs
+			if j > 0 && uuids[j-1].Compare(uuid) >= 0 {
+				t.Fatal("not strictly increasing", uuids[j-1], uuid)
+			}
+		}
+		all = append(all, uuids...)
+	}
+	slices.SortFunc(all, UUID.Compare)
+	for i := 1; i < len(all); i++ {
+		if all[i-1] == all[i] {
+			t.Fatal("duplicate", all[i])
+		}
+	}
+	var zero MonotonicGenerator
+	if a, b := zero.New(), zero.New(); a.Compare(b) >= 0 {
+		t.Error("zero generator is not monotonic", a, b)
+	}
+}
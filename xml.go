@@ -0,0 +1,19 @@
+package uuid
+
+import "encoding/xml"
+
+// MarshalXMLAttr provides support for the interface xml.MarshalerAttr, so the
+// UUID can be used with the `xml:"name,attr"` struct tag. The attribute value
+// is the canonical string representation.
+func (u UUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: u.String()}, nil
+}
+
+// UnmarshalXMLAttr provides support for the interface xml.UnmarshalerAttr.
+// The attribute value is parsed with UnmarshalText.
+func (u *UUID) UnmarshalXMLAttr(attr xml.Attr) error {
+	if err := parse(u, attr.Value); err != nil {
+		return parseError(attr.Value, err)
+	}
+	return nil
+}
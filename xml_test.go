@@ -0,0 +1,33 @@
+package uuid
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestXML(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		ID      UUID     `xml:"id,attr"`
+		Parent  UUID     `xml:"parent"`
+	}
+	src := item{ID: New(), Parent: New()}
+	data, err := xml.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<item id="` + src.ID.String() + `"><parent>` + src.Parent.String() + `</parent></item>`
+	if string(data) != want {
+		t.Error("bad xml", string(data))
+	}
+	var dst item
+	if err := xml.Unmarshal(data, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.ID.Equal(src.ID) || !dst.Parent.Equal(src.Parent) {
+		t.Error("bad restore", dst)
+	}
+	if err := xml.Unmarshal([]byte(`<item id="6ba7b810"></item>`), &dst); err == nil {
+		t.Error("bad invalid attribute")
+	}
+}
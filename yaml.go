@@ -0,0 +1,17 @@
+package uuid
+
+// MarshalYAML provides support for the yaml.Marshaler interface of the
+// gopkg.in/yaml packages. Returns the canonical string representation.
+func (u UUID) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// UnmarshalYAML provides support for the yaml.Unmarshaler interface of the
+// gopkg.in/yaml packages. The YAML string node is parsed with UnmarshalText.
+func (u *UUID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(str))
+}
@@ -0,0 +1,34 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestYAML(t *testing.T) {
+	uuid := New()
+	value, err := uuid.MarshalYAML()
+	if err != nil || value != uuid.String() {
+		t.Error("bad marshal", value, err)
+	}
+	var newUUID UUID
+	err = newUUID.UnmarshalYAML(func(v interface{}) error {
+		*(v.(*string)) = uuid.String()
+		return nil
+	})
+	if err != nil || !newUUID.Equal(uuid) {
+		t.Error("bad unmarshal", newUUID, err)
+	}
+	err = newUUID.UnmarshalYAML(func(v interface{}) error {
+		*(v.(*string)) = "6ba7b810"
+		return nil
+	})
+	if err == nil {
+		t.Error("bad unmarshal of invalid string")
+	}
+	errNode := errors.New("not a string node")
+	err = newUUID.UnmarshalYAML(func(v interface{}) error { return errNode })
+	if err != errNode {
+		t.Error("bad unmarshal error", err)
+	}
+}